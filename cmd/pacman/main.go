@@ -1,7 +1,9 @@
 package main
 
 import (
+	"flag"
 	"log"
+	"os"
 
 	"pacman/internal/game"
 
@@ -9,7 +11,30 @@ import (
 )
 
 func main() {
-	g := game.New()
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(runValidate(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
+	mazePath := flag.String("maze", "", "play on a maze loaded from this file (text, or Tiled .tmx/.tmj)")
+	playlistPath := flag.String("playlist", "", "rotate mazes by level as listed in this file (see assets/playlist.txt)")
+	flag.Parse()
+
+	opts := game.OptionsFromEnv()
+	if *mazePath != "" {
+		m, err := game.LoadMaze(*mazePath)
+		if err != nil {
+			log.Fatalf("pacman: loading maze: %v", err)
+		}
+		opts.Maze = m
+	}
+	if *playlistPath != "" {
+		p, err := game.LoadPlaylist(*playlistPath)
+		if err != nil {
+			log.Fatalf("pacman: loading playlist: %v", err)
+		}
+		opts.Playlist = p
+	}
+	g := game.NewWithOptions(opts)
 	ebiten.SetWindowTitle("Pacman (Go + Ebiten)")
 	ebiten.SetWindowResizable(false)
 	ebiten.SetWindowSize(g.ScreenWidth(), g.ScreenHeight())
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"pacman/internal/game"
+	"pacman/internal/mazecheck"
+)
+
+// mazeExts are the file extensions picked up when validating a directory.
+var mazeExts = map[string]bool{".txt": true, ".tmx": true, ".tmj": true, ".json": true}
+
+// runValidate checks the maze files given as arguments, or found in the
+// directories given. Good mazes are listed on out; problems, load errors and
+// usage go to errOut. It returns the exit status: 0 when every maze is good,
+// 1 when any is not, 2 on bad usage.
+func runValidate(args []string, out, errOut io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(errOut, "usage: pacman validate <dir|file>...")
+		return 2
+	}
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			fmt.Fprintf(errOut, "%s: %v\n", arg, err)
+			return 1
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			fmt.Fprintf(errOut, "%s: %v\n", arg, err)
+			return 1
+		}
+		for _, e := range entries {
+			if !e.IsDir() && mazeExts[strings.ToLower(filepath.Ext(e.Name()))] {
+				paths = append(paths, filepath.Join(arg, e.Name()))
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	status := 0
+	for _, path := range paths {
+		m, err := game.LoadMaze(path)
+		if err != nil {
+			fmt.Fprintln(errOut, err)
+			status = 1
+			continue
+		}
+		issues := mazecheck.Validate(m)
+		if len(issues) == 0 {
+			fmt.Fprintf(out, "%s: ok\n", path)
+			continue
+		}
+		status = 1
+		for _, is := range issues {
+			fmt.Fprintf(errOut, "%s: %v\n", path, is)
+		}
+	}
+	return status
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateShippedMazes(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := runValidate([]string{"../../assets/mazes"}, &out, &errOut); code != 0 {
+		t.Fatalf("exit %d, stderr:\n%s", code, errOut.String())
+	}
+	for _, name := range []string{"classic.txt", "pink.txt"} {
+		if !strings.Contains(out.String(), name+": ok") {
+			t.Errorf("expected %s to be listed as ok, got:\n%s", name, out.String())
+		}
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("expected nothing on stderr, got:\n%s", errOut.String())
+	}
+}
+
+func TestValidateReportsBrokenMaze(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, s string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("good.txt", "#########\n#...1...#\n#.##-##.#\n#.#234#.#\n#.#####.#\nT...P...T\n#########\n")
+	write("broken.txt", "#####\n#.P.#\n#####\n")
+	write("garbled.txt", "#x#\n")
+	write("notes.md", "not a maze\n")
+
+	var out, errOut bytes.Buffer
+	if code := runValidate([]string{dir}, &out, &errOut); code != 1 {
+		t.Fatalf("expected exit 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "good.txt: ok") || strings.Contains(out.String(), "broken.txt") {
+		t.Errorf("stdout should list only the good maze, got:\n%s", out.String())
+	}
+	for _, want := range []string{"broken.txt: house: no ghost house door", "garbled.txt: line 1 column 2: unknown tile"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Errorf("expected %q on stderr, got:\n%s", want, errOut.String())
+		}
+	}
+	if strings.Contains(out.String()+errOut.String(), "notes.md") {
+		t.Error("files that are not mazes should be skipped")
+	}
+}
+
+func TestValidateUsage(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := runValidate(nil, &out, &errOut); code != 2 {
+		t.Fatalf("expected exit 2 without arguments, got %d", code)
+	}
+	if out.Len() != 0 || !strings.Contains(errOut.String(), "usage:") {
+		t.Fatalf("usage should go to stderr, got stdout %q stderr %q", out.String(), errOut.String())
+	}
+	errOut.Reset()
+	if code := runValidate([]string{"no-such-dir"}, &out, &errOut); code != 1 || !strings.Contains(errOut.String(), "no-such-dir") {
+		t.Fatalf("expected exit 1 for a missing path, got %d: %s", code, errOut.String())
+	}
+}
@@ -0,0 +1,222 @@
+// Package cutscene plays scripted scenes: a timeline of cues that place and
+// move actors, change their sprites and trigger sounds on a tick schedule.
+// It knows nothing about the maze or drawing, so scenes can be played
+// headlessly and their outcome checked in tests.
+package cutscene
+
+import "pacman/internal/entities"
+
+// Actor is an entity on stage.
+type Actor struct {
+	Name    string
+	X, Y    float64
+	Dir     entities.Direction // direction of the last move, for facing
+	Sprite  string
+	Visible bool
+}
+
+type cueKind int
+
+const (
+	cuePlace cueKind = iota
+	cueMove
+	cueSprite
+	cueHide
+	cueSound
+)
+
+type cue struct {
+	kind     cueKind
+	at, end  int // end is only used by moves
+	actor    string
+	x, y     float64
+	sprite   string
+	sound    string
+	fromX    float64 // set when a move starts
+	fromY    float64
+	started  bool
+	finished bool
+}
+
+// Timeline is a script of cues. Build it with the chaining methods; ticks
+// count from 0 and Length is the last tick played.
+type Timeline struct {
+	Length int
+	cues   []cue
+}
+
+// New returns an empty timeline lasting length ticks.
+func New(length int) *Timeline {
+	return &Timeline{Length: length}
+}
+
+// Place shows an actor at (x, y) with the given sprite at tick at, adding
+// it to the stage the first time.
+func (tl *Timeline) Place(at int, actor string, x, y float64, sprite string) *Timeline {
+	tl.cues = append(tl.cues, cue{kind: cuePlace, at: at, actor: actor, x: x, y: y, sprite: sprite})
+	return tl
+}
+
+// Move glides an actor in a straight line from wherever it is at tick from
+// to (x, y) at tick to.
+func (tl *Timeline) Move(from, to int, actor string, x, y float64) *Timeline {
+	tl.cues = append(tl.cues, cue{kind: cueMove, at: from, end: to, actor: actor, x: x, y: y})
+	return tl
+}
+
+// Sprite changes an actor's sprite at tick at.
+func (tl *Timeline) Sprite(at int, actor, sprite string) *Timeline {
+	tl.cues = append(tl.cues, cue{kind: cueSprite, at: at, actor: actor, sprite: sprite})
+	return tl
+}
+
+// Hide takes an actor off stage at tick at.
+func (tl *Timeline) Hide(at int, actor string) *Timeline {
+	tl.cues = append(tl.cues, cue{kind: cueHide, at: at, actor: actor})
+	return tl
+}
+
+// Sound cues a named sound at tick at.
+func (tl *Timeline) Sound(at int, name string) *Timeline {
+	tl.cues = append(tl.cues, cue{kind: cueSound, at: at, sound: name})
+	return tl
+}
+
+// Player plays a timeline one tick at a time.
+type Player struct {
+	length int
+	cues   []cue
+	tick   int
+	actors []*Actor // in order of first appearance
+}
+
+// Start returns a player positioned before the first tick. Several players
+// can run the same timeline independently.
+func (tl *Timeline) Start() *Player {
+	p := &Player{length: tl.Length, cues: append([]cue(nil), tl.cues...)}
+	return p
+}
+
+// Tick returns the number of ticks played so far.
+func (p *Player) Tick() int { return p.tick }
+
+// Done reports whether the scene has ended.
+func (p *Player) Done() bool { return p.tick > p.length }
+
+// Step plays one tick and returns the sounds cued on it.
+func (p *Player) Step() []string {
+	if p.Done() {
+		return nil
+	}
+	sounds := p.apply(p.tick)
+	p.tick++
+	return sounds
+}
+
+// Skip jumps to the end of the scene, leaving every actor where the script
+// puts it last. Sounds cued along the way are dropped.
+func (p *Player) Skip() {
+	for !p.Done() {
+		p.Step()
+	}
+}
+
+// Actors returns the actors on stage in the order they first appeared.
+func (p *Player) Actors() []Actor {
+	out := make([]Actor, len(p.actors))
+	for i, a := range p.actors {
+		out[i] = *a
+	}
+	return out
+}
+
+// Actor returns the named actor and whether it has appeared yet.
+func (p *Player) Actor(name string) (Actor, bool) {
+	if a := p.find(name); a != nil {
+		return *a, true
+	}
+	return Actor{}, false
+}
+
+func (p *Player) find(name string) *Actor {
+	for _, a := range p.actors {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func (p *Player) actor(name string) *Actor {
+	if a := p.find(name); a != nil {
+		return a
+	}
+	a := &Actor{Name: name}
+	p.actors = append(p.actors, a)
+	return a
+}
+
+// apply runs every cue due on tick t, in the order they were added.
+func (p *Player) apply(t int) []string {
+	var sounds []string
+	for i := range p.cues {
+		c := &p.cues[i]
+		if c.finished || c.at > t {
+			continue
+		}
+		switch c.kind {
+		case cuePlace:
+			a := p.actor(c.actor)
+			a.X, a.Y, a.Sprite, a.Visible = c.x, c.y, c.sprite, true
+			c.finished = true
+		case cueMove:
+			a := p.actor(c.actor)
+			if !c.started {
+				c.fromX, c.fromY, c.started = a.X, a.Y, true
+				a.Dir = directionOf(c.x-a.X, c.y-a.Y)
+			}
+			f := 1.0
+			if c.end > c.at {
+				f = float64(t-c.at) / float64(c.end-c.at)
+			}
+			if f >= 1 {
+				f, c.finished = 1, true
+			}
+			a.X = c.fromX + (c.x-c.fromX)*f
+			a.Y = c.fromY + (c.y-c.fromY)*f
+		case cueSprite:
+			p.actor(c.actor).Sprite = c.sprite
+			c.finished = true
+		case cueHide:
+			p.actor(c.actor).Visible = false
+			c.finished = true
+		case cueSound:
+			sounds = append(sounds, c.sound)
+			c.finished = true
+		}
+	}
+	return sounds
+}
+
+// directionOf returns the main direction of a move by (dx, dy).
+func directionOf(dx, dy float64) entities.Direction {
+	switch {
+	case dx == 0 && dy == 0:
+		return entities.DirNone
+	case abs(dx) >= abs(dy) && dx < 0:
+		return entities.DirLeft
+	case abs(dx) >= abs(dy):
+		return entities.DirRight
+	case dy < 0:
+		return entities.DirUp
+	default:
+		return entities.DirDown
+	}
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,101 @@
+package cutscene
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func chase() *Timeline {
+	return New(100).
+		Sound(0, "intermission").
+		Place(0, "pacman", 200, 50, "pacman").
+		Place(10, "ghost", 240, 50, "ghost-red").
+		Move(0, 50, "pacman", 0, 50).
+		Move(10, 60, "ghost", 40, 50).
+		Sprite(60, "ghost", "ghost-frightened").
+		Move(60, 100, "ghost", 240, 50).
+		Hide(80, "pacman")
+}
+
+func TestTimelinePlaysHeadlessly(t *testing.T) {
+	p := chase().Start()
+	var sounds []string
+	for !p.Done() {
+		sounds = append(sounds, p.Step()...)
+	}
+	if p.Tick() != 101 {
+		t.Fatalf("expected ticks 0 to 100 to play, played %d", p.Tick())
+	}
+	if len(sounds) != 1 || sounds[0] != "intermission" {
+		t.Fatalf("expected one intermission sound, got %v", sounds)
+	}
+	pac, _ := p.Actor("pacman")
+	if pac.X != 0 || pac.Y != 50 || pac.Visible {
+		t.Fatalf("pacman should end hidden at 0,50, got %+v", pac)
+	}
+	ghost, _ := p.Actor("ghost")
+	if ghost.X != 240 || ghost.Sprite != "ghost-frightened" || ghost.Dir != entities.DirRight {
+		t.Fatalf("ghost should end frightened and heading right at 240, got %+v", ghost)
+	}
+}
+
+func TestMoveInterpolatesFromCurrentPosition(t *testing.T) {
+	p := chase().Start()
+	for p.Tick() <= 25 {
+		p.Step()
+	}
+	pac, _ := p.Actor("pacman")
+	if pac.X != 100 || pac.Dir != entities.DirLeft {
+		t.Fatalf("pacman should be half way and facing left at tick 25, got %+v", pac)
+	}
+	if _, ok := p.Actor("nobody"); ok {
+		t.Fatal("unknown actors should not exist")
+	}
+}
+
+func TestActorsAppearInOrder(t *testing.T) {
+	p := chase().Start()
+	p.Step()
+	if got := len(p.Actors()); got != 1 {
+		t.Fatalf("only pacman should be on stage at first, got %d actors", got)
+	}
+	p.Skip()
+	actors := p.Actors()
+	if len(actors) != 2 || actors[0].Name != "pacman" || actors[1].Name != "ghost" {
+		t.Fatalf("unexpected actors %+v", actors)
+	}
+}
+
+func TestSkipMatchesFullPlay(t *testing.T) {
+	full := chase().Start()
+	for !full.Done() {
+		full.Step()
+	}
+	skipped := chase().Start()
+	skipped.Step()
+	skipped.Skip()
+	if !skipped.Done() {
+		t.Fatal("skip should end the scene")
+	}
+	for _, a := range full.Actors() {
+		b, _ := skipped.Actor(a.Name)
+		if a != b {
+			t.Fatalf("skipping should leave %s at %+v, got %+v", a.Name, a, b)
+		}
+	}
+	if skipped.Step() != nil {
+		t.Fatal("a finished scene should not cue sounds")
+	}
+}
+
+func TestPlayersAreIndependent(t *testing.T) {
+	tl := chase()
+	a := tl.Start()
+	a.Skip()
+	b := tl.Start()
+	b.Step()
+	if pac, _ := b.Actor("pacman"); pac.X != 200 {
+		t.Fatalf("a second player should start fresh, got pacman at %.0f", pac.X)
+	}
+}
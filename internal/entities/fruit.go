@@ -0,0 +1,38 @@
+package entities
+
+// FruitKind identifies a bonus fruit (or, for the later levels, a bonus item).
+type FruitKind int
+
+const (
+	FruitCherry FruitKind = iota
+	FruitStrawberry
+	FruitOrange
+	FruitApple
+	FruitMelon
+	FruitGalaxian
+	FruitBell
+	FruitKey
+)
+
+func (k FruitKind) String() string {
+	switch k {
+	case FruitCherry:
+		return "cherry"
+	case FruitStrawberry:
+		return "strawberry"
+	case FruitOrange:
+		return "orange"
+	case FruitApple:
+		return "apple"
+	case FruitMelon:
+		return "melon"
+	case FruitGalaxian:
+		return "galaxian"
+	case FruitBell:
+		return "bell"
+	case FruitKey:
+		return "key"
+	default:
+		return "unknown"
+	}
+}
@@ -4,11 +4,87 @@ type Ghost struct {
 	X, Y       float64
 	CurrentDir Direction
 	State      GhostState
+	Kind       GhostKind
+	// DotCounter counts pellets eaten while this ghost waits in the house.
+	DotCounter int
 }
 
 type GhostState int
 
 const (
-	GhostNormal GhostState = iota
-	GhostEaten
+	GhostScatter    GhostState = iota // roaming toward its home corner
+	GhostChase                        // roaming toward its personality target
+	GhostFrightened                   // blue, slow and edible
+	GhostEaten                        // eyes heading back to the house
+	GhostInHouse                      // waiting inside the ghost house
+	GhostLeaving                      // moving out through the door
+	GhostEntering                     // eyes moving in through the door to respawn
 )
+
+func (s GhostState) String() string {
+	switch s {
+	case GhostScatter:
+		return "scatter"
+	case GhostChase:
+		return "chase"
+	case GhostFrightened:
+		return "frightened"
+	case GhostEaten:
+		return "eaten"
+	case GhostInHouse:
+		return "in house"
+	case GhostLeaving:
+		return "leaving"
+	case GhostEntering:
+		return "entering"
+	default:
+		return "unknown"
+	}
+}
+
+// IsRoaming reports whether the ghost is moving freely through the maze
+// under the scatter/chase rules.
+func (s GhostState) IsRoaming() bool {
+	return s == GhostScatter || s == GhostChase
+}
+
+// IsEyes reports whether only the ghost's eyes are left, on their way home.
+func (s GhostState) IsEyes() bool {
+	return s == GhostEaten || s == GhostEntering
+}
+
+// GhostKind identifies one of the four classic ghosts. It decides both the
+// ghost's colour and how it picks its target tile while chasing.
+type GhostKind int
+
+const (
+	GhostRed    GhostKind = iota // chases the player's tile directly
+	GhostPink                    // ambushes four tiles ahead of the player
+	GhostCyan                    // flanks using a vector from the red ghost
+	GhostOrange                  // chases from afar, retreats when close
+)
+
+func (k GhostKind) String() string {
+	switch k {
+	case GhostRed:
+		return "red"
+	case GhostPink:
+		return "pink"
+	case GhostCyan:
+		return "cyan"
+	case GhostOrange:
+		return "orange"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseGhostKind converts a colour name such as "pink" into a GhostKind.
+func ParseGhostKind(s string) (GhostKind, bool) {
+	for _, k := range []GhostKind{GhostRed, GhostPink, GhostCyan, GhostOrange} {
+		if k.String() == s {
+			return k, true
+		}
+	}
+	return GhostRed, false
+}
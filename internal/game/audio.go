@@ -17,11 +17,15 @@ type SoundData struct {
 }
 
 type AudioManager struct {
-	ctx         *audio.Context
-	pellet      *SoundData
-	powerPellet *SoundData
-	ghostEaten  *SoundData
-	death       *SoundData
+	ctx          *audio.Context
+	pellet       *SoundData
+	powerPellet  *SoundData
+	ghostEaten   *SoundData
+	death        *SoundData
+	fruit        *SoundData
+	extraLife    *SoundData
+	intermission *SoundData
+	muted        bool
 }
 
 var (
@@ -69,7 +73,23 @@ func NewAudioManager(soundsDir string) *AudioManager {
 	if sd, _ := loadSoundData(soundsDir, "death.wav"); sd != nil {
 		am.death = sd
 	} else {
-		am.death = &SoundData{raw: synthBeepWAV(44100, 400, 220)}
+		// As long as the death animation, so the two end together
+		am.death = &SoundData{raw: synthBeepWAV(44100, deathTicks*1000/updatesPerSecond, 220)}
+	}
+	if sd, _ := loadSoundData(soundsDir, "fruit.wav"); sd != nil {
+		am.fruit = sd
+	} else {
+		am.fruit = &SoundData{raw: synthBeepWAV(44100, 180, 1046)}
+	}
+	if sd, _ := loadSoundData(soundsDir, "extralife.wav"); sd != nil {
+		am.extraLife = sd
+	} else {
+		am.extraLife = &SoundData{raw: synthBeepWAV(44100, 300, 1318)}
+	}
+	if sd, _ := loadSoundData(soundsDir, "intermission.wav"); sd != nil {
+		am.intermission = sd
+	} else {
+		am.intermission = &SoundData{raw: synthBeepWAV(44100, 600, 523)}
 	}
 	return am
 }
@@ -88,7 +108,7 @@ func loadSoundData(dir, file string) (*SoundData, error) {
 }
 
 func (am *AudioManager) play(sd *SoundData) {
-	if am == nil || am.ctx == nil || sd == nil || len(sd.raw) == 0 {
+	if am == nil || am.ctx == nil || am.muted || sd == nil || len(sd.raw) == 0 {
 		return
 	}
 	// Decode from bytes each time to allow overlapping plays
@@ -104,10 +124,21 @@ func (am *AudioManager) play(sd *SoundData) {
 	p.Play()
 }
 
-func (am *AudioManager) PlayPellet()      { am.play(am.pellet) }
-func (am *AudioManager) PlayPowerPellet() { am.play(am.powerPellet) }
-func (am *AudioManager) PlayGhostEaten()  { am.play(am.ghostEaten) }
-func (am *AudioManager) PlayDeath()       { am.play(am.death) }
+// SetMuted silences or restores all sounds, such as during the attract
+// mode demo.
+func (am *AudioManager) SetMuted(muted bool) {
+	if am != nil {
+		am.muted = muted
+	}
+}
+
+func (am *AudioManager) PlayPellet()       { am.play(am.pellet) }
+func (am *AudioManager) PlayPowerPellet()  { am.play(am.powerPellet) }
+func (am *AudioManager) PlayGhostEaten()   { am.play(am.ghostEaten) }
+func (am *AudioManager) PlayDeath()        { am.play(am.death) }
+func (am *AudioManager) PlayFruit()        { am.play(am.fruit) }
+func (am *AudioManager) PlayExtraLife()    { am.play(am.extraLife) }
+func (am *AudioManager) PlayIntermission() { am.play(am.intermission) }
 
 // synthBeepWAV returns a minimal 16-bit PCM mono WAV of a sine beep.
 func synthBeepWAV(sampleRate int, durationMs int, freq float64) []byte {
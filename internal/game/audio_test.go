@@ -11,4 +11,7 @@ func TestAudioManagerNoAssets(t *testing.T) {
 	am.PlayPowerPellet()
 	am.PlayGhostEaten()
 	am.PlayDeath()
+	am.PlayFruit()
+	am.PlayExtraLife()
+	am.PlayIntermission()
 }
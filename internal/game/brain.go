@@ -0,0 +1,165 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"pacman/internal/entities"
+)
+
+// DefaultGhostBrain is the brain used for ghosts without an explicit choice.
+const DefaultGhostBrain = "classic"
+
+// brainSeed seeds each game's brain RNG at the start of every life and
+// level, so a brain's choices depend only on the game it plays in.
+const brainSeed = 1
+
+// WorldView is the read-only view of the game that ghost brains decide from.
+type WorldView interface {
+	// Width and Height are the maze size in tiles.
+	Width() int
+	Height() int
+	// IsWall reports whether a tile blocks ghosts roaming the maze.
+	IsWall(x, y int) bool
+	PlayerTile() (int, int)
+	PlayerDir() entities.Direction
+	// Ghosts returns copies of every ghost, including the one deciding.
+	Ghosts() []entities.Ghost
+	GhostTile(gh entities.Ghost) (int, int)
+	// Mode is the current global ghost mode.
+	Mode() GhostMode
+	Level() int
+	Tick() int
+	// Rand is the game's own random source for brains. It is reseeded the
+	// same way every life, so runs can be repeated and compared.
+	Rand() *rand.Rand
+}
+
+// GhostBrain chooses where a roaming ghost goes next. It is consulted only at
+// intersections, i.e. when a ghost on a tile center has more than one legal
+// option; frightened ghosts, eyes and ghosts in the house are moved by the
+// game itself. Options never include reversing and are listed in up, left,
+// down, right order. Returning anything outside options picks the first one.
+type GhostBrain interface {
+	ChooseDirection(w WorldView, gh entities.Ghost, options []entities.Direction) entities.Direction
+}
+
+// GhostBrainFunc adapts a function to the GhostBrain interface.
+type GhostBrainFunc func(w WorldView, gh entities.Ghost, options []entities.Direction) entities.Direction
+
+func (f GhostBrainFunc) ChooseDirection(w WorldView, gh entities.Ghost, options []entities.Direction) entities.Direction {
+	return f(w, gh, options)
+}
+
+var (
+	brainsMu sync.RWMutex
+	brains   = make(map[string]GhostBrain)
+)
+
+// RegisterGhostBrain makes a brain available by name for Options.GhostBrains.
+// It panics if the name is already taken or the brain is nil, so it is
+// meant to be called from init functions.
+func RegisterGhostBrain(name string, b GhostBrain) {
+	brainsMu.Lock()
+	defer brainsMu.Unlock()
+	if b == nil {
+		panic("game: RegisterGhostBrain brain is nil")
+	}
+	if _, dup := brains[name]; dup {
+		panic(fmt.Sprintf("game: RegisterGhostBrain called twice for %q", name))
+	}
+	brains[name] = b
+}
+
+// GhostBrainNames returns the registered brain names in sorted order.
+func GhostBrainNames() []string {
+	brainsMu.RLock()
+	defer brainsMu.RUnlock()
+	names := make([]string, 0, len(brains))
+	for name := range brains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupGhostBrain(name string) (GhostBrain, bool) {
+	brainsMu.RLock()
+	defer brainsMu.RUnlock()
+	b, ok := brains[name]
+	return b, ok
+}
+
+func init() {
+	RegisterGhostBrain("classic", GhostBrainFunc(classicBrain))
+	RegisterGhostBrain("random", GhostBrainFunc(randomBrain))
+}
+
+// classicBrain steers toward the arcade personality target.
+func classicBrain(w WorldView, gh entities.Ghost, options []entities.Direction) entities.Direction {
+	tx, ty := ClassicTarget(w, gh)
+	return DirectionToward(w, gh, options, tx, ty)
+}
+
+// randomBrain wanders, picking any option with equal chance.
+func randomBrain(w WorldView, gh entities.Ghost, options []entities.Direction) entities.Direction {
+	return options[w.Rand().Intn(len(options))]
+}
+
+func (g *Game) resetBrainRNG() {
+	g.brainRNG = rand.New(rand.NewSource(brainSeed))
+}
+
+// brainDirection asks the ghost's brain for a direction when it has a
+// real choice to make.
+func (g *Game) brainDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
+	options := g.ghostOptions(gh, gx, gy)
+	switch len(options) {
+	case 0:
+		return entities.DirNone
+	case 1:
+		return options[0]
+	}
+	brain := g.brains[gh.Kind]
+	if brain == nil {
+		brain, _ = lookupGhostBrain(DefaultGhostBrain)
+	}
+	d := brain.ChooseDirection(g.view(), *gh, options)
+	for _, o := range options {
+		if o == d {
+			return d
+		}
+	}
+	return options[0]
+}
+
+// worldView exposes a Game to brains without handing out the Game itself.
+type worldView struct {
+	g *Game
+}
+
+func (g *Game) view() WorldView { return worldView{g: g} }
+
+func (v worldView) Width() int                    { return v.g.tileMap.Width }
+func (v worldView) Height() int                   { return v.g.tileMap.Height }
+func (v worldView) IsWall(x, y int) bool          { return v.g.tileMap.IsWall(x, y) }
+func (v worldView) PlayerTile() (int, int)        { return v.g.playerGrid() }
+func (v worldView) PlayerDir() entities.Direction { return v.g.player.CurrentDir }
+func (v worldView) Mode() GhostMode               { return v.g.GhostMode() }
+func (v worldView) Level() int                    { return v.g.level }
+func (v worldView) Tick() int                     { return v.g.tickCounter }
+func (v worldView) Rand() *rand.Rand              { return v.g.brainRNG }
+
+func (v worldView) Ghosts() []entities.Ghost {
+	out := make([]entities.Ghost, len(v.g.ghosts))
+	for i, gh := range v.g.ghosts {
+		out[i] = *gh
+	}
+	return out
+}
+
+func (v worldView) GhostTile(gh entities.Ghost) (int, int) {
+	return v.g.ghostGrid(&gh)
+}
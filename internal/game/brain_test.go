@@ -0,0 +1,135 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func TestBuiltinGhostBrainsRegistered(t *testing.T) {
+	names := GhostBrainNames()
+	want := map[string]bool{"classic": false, "random": false}
+	for _, n := range names {
+		if _, ok := want[n]; ok {
+			want[n] = true
+		}
+	}
+	for n, found := range want {
+		if !found {
+			t.Fatalf("expected built-in brain %q to be registered, got %v", n, names)
+		}
+	}
+}
+
+func TestRegisterGhostBrainRejectsDuplicates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic when registering a name twice")
+		}
+	}()
+	RegisterGhostBrain(DefaultGhostBrain, GhostBrainFunc(classicBrain))
+}
+
+func TestGhostBrainChosenPerGhostFromOptions(t *testing.T) {
+	var asked []entities.GhostKind
+	g := New()
+	g.brains[entities.GhostPink] = GhostBrainFunc(func(w WorldView, gh entities.Ghost, options []entities.Direction) entities.Direction {
+		asked = append(asked, gh.Kind)
+		return options[0]
+	})
+	pink := g.ghostOfKind(entities.GhostPink)
+	pink.State = entities.GhostChase
+	placeGhost(g, pink, 6, 5, entities.DirUp) // crossroads: a real choice
+	if d := g.chooseGhostDirection(pink, 6, 5); d != entities.DirUp {
+		t.Fatalf("expected the custom brain's choice, got %v", d)
+	}
+	red := g.ghostOfKind(entities.GhostRed)
+	placeGhost(g, red, 6, 5, entities.DirUp)
+	g.chooseGhostDirection(red, 6, 5)
+	if len(asked) != 1 || asked[0] != entities.GhostPink {
+		t.Fatalf("expected the custom brain to be consulted for pink only, got %v", asked)
+	}
+}
+
+func TestGhostBrainInvalidChoiceFallsBack(t *testing.T) {
+	g := New()
+	g.brains[entities.GhostRed] = GhostBrainFunc(func(w WorldView, gh entities.Ghost, options []entities.Direction) entities.Direction {
+		return reverseDir(gh.CurrentDir)
+	})
+	red := g.ghostOfKind(entities.GhostRed)
+	placeGhost(g, red, 6, 5, entities.DirUp)
+	if d := g.chooseGhostDirection(red, 6, 5); d == entities.DirDown {
+		t.Fatal("a brain must not be able to make a ghost reverse")
+	}
+}
+
+func TestRandomBrainIsPerGame(t *testing.T) {
+	options := []entities.Direction{entities.DirUp, entities.DirLeft, entities.DirDown, entities.DirRight}
+	choices := func(g *Game, n int) []entities.Direction {
+		var out []entities.Direction
+		for i := 0; i < n; i++ {
+			out = append(out, randomBrain(g.view(), entities.Ghost{}, options))
+		}
+		return out
+	}
+	busy, quiet := New(), New()
+	choices(busy, 50) // another game's choices must not shift this one's
+	want := choices(New(), 20)
+	got := choices(quiet, 20)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("choice %d = %v, want %v: random brains share state between games", i, got[i], want[i])
+		}
+	}
+	choices(quiet, 5)
+	quiet.resetPositions()
+	if again := choices(quiet, 20); again[0] != want[0] || again[19] != want[19] {
+		t.Fatal("the brain RNG should be reseeded every life")
+	}
+}
+
+func TestParseGhostBrains(t *testing.T) {
+	got, err := ParseGhostBrains("red=random, Pink = classic")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got[entities.GhostRed] != "random" || got[entities.GhostPink] != "classic" || len(got) != 2 {
+		t.Fatalf("unexpected result %v", got)
+	}
+	if _, err := ParseGhostBrains("purple=classic"); err == nil {
+		t.Fatal("expected an error for an unknown ghost")
+	}
+	if _, err := ParseGhostBrains("red"); err == nil {
+		t.Fatal("expected an error for a missing brain name")
+	}
+}
+
+func TestUnknownGhostBrainUsesDefault(t *testing.T) {
+	g := NewWithOptions(Options{GhostBrains: map[entities.GhostKind]string{entities.GhostRed: "no-such-brain"}})
+	if g.brains[entities.GhostRed] == nil {
+		t.Fatal("expected a fallback brain for an unknown name")
+	}
+}
+
+// BenchmarkGhostBrains runs the same stretch of play with every ghost driven
+// by each registered brain.
+func BenchmarkGhostBrains(b *testing.B) {
+	for _, name := range GhostBrainNames() {
+		b.Run(name, func(b *testing.B) {
+			brains := make(map[entities.GhostKind]string)
+			for _, kind := range ghostSpawnKinds {
+				brains[kind] = name
+			}
+			for i := 0; i < b.N; i++ {
+				g := NewWithOptions(Options{GhostBrains: brains})
+				g.enteringName = false
+				for tick := 0; tick < 600; tick++ {
+					g.tickCounter++
+					g.updateGhostMode()
+					g.updateGhostHouse()
+					g.updateGhosts()
+				}
+			}
+		})
+	}
+}
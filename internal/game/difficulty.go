@@ -0,0 +1,54 @@
+package game
+
+import "strings"
+
+// Difficulty selects a set of behaviour tweaks on top of the level table.
+// The zero value is DifficultyNormal.
+type Difficulty int
+
+const (
+	DifficultyNormal Difficulty = iota
+	DifficultyEasy
+	DifficultyHard
+)
+
+// difficulties lists the difficulties from easiest to hardest, the order the
+// settings menu steps through them.
+var difficulties = []Difficulty{DifficultyEasy, DifficultyNormal, DifficultyHard}
+
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyEasy:
+		return "Easy"
+	case DifficultyHard:
+		return "Hard"
+	default:
+		return "Normal"
+	}
+}
+
+// ParseDifficulty converts a name such as "hard" into a Difficulty.
+func ParseDifficulty(s string) (Difficulty, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "easy":
+		return DifficultyEasy, true
+	case "normal", "":
+		return DifficultyNormal, true
+	case "hard":
+		return DifficultyHard, true
+	}
+	return DifficultyNormal, false
+}
+
+// difficultySpec holds the settings that vary with difficulty.
+type difficultySpec struct {
+	frightened frightenedPolicy
+	// frightenedScale stretches the level's frightened time.
+	frightenedScale float64
+}
+
+var difficultyTable = map[Difficulty]difficultySpec{
+	DifficultyEasy:   {frightened: frightenedRandom, frightenedScale: 1.5},
+	DifficultyNormal: {frightened: frightenedRandom, frightenedScale: 1},
+	DifficultyHard:   {frightened: frightenedFlee, frightenedScale: 1},
+}
@@ -6,10 +6,11 @@ import (
 	"math"
 	"math/rand"
 	"sort"
-	"strings"
 	"time"
 
+	"pacman/internal/cutscene"
 	"pacman/internal/entities"
+	"pacman/internal/pathfinding"
 	tm "pacman/internal/tilemap"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -26,7 +27,17 @@ const (
 	playerSpeedPixelsPerUpdate = playerSpeedPixelsPerSecond / updatesPerSecond
 	ghostSpeedPixelsPerSecond  = 630.0 // 420.0 * 1.5
 	ghostSpeedPixelsPerUpdate  = ghostSpeedPixelsPerSecond / updatesPerSecond
-	frightenedDurationUpdates  = 120 // 120 ticks = 2 seconds at 60 UPS
+	ghostCenterEpsilon         = 0.01 // distance at which a ghost counts as on a tile center
+	frightenedDurationUpdates  = 120  // 120 ticks = 2 seconds at 60 UPS
+
+	// State machine timings, in ticks
+	readyTicks          = 2 * updatesPerSecond
+	lifeLostFreezeTicks = updatesPerSecond
+	deathTicks          = 3 * updatesPerSecond / 2
+	levelCompleteTicks  = 2 * updatesPerSecond
+	levelFlashTicks     = 12 // ticks per wall colour while the cleared maze flashes
+	gameOverTicks       = 3 * updatesPerSecond
+	hitStopTicks        = updatesPerSecond // freeze after eating a ghost
 
 	// Alignment and movement constants
 	// Alignment threshold for turn detection and auto-centering.
@@ -54,6 +65,8 @@ const (
 
 type Game struct {
 	tileMap             *tm.TileMap
+	mazeSource          *tm.TileMap // configured maze the board was copied from; nil for the built-in one
+	paths               *pathfinding.Cache
 	player              *entities.Player
 	ghosts              []*entities.Ghost
 	score               int
@@ -63,27 +76,66 @@ type Game struct {
 	enteringName        bool
 	showingLeaderboard  bool
 	lives               int
+	nextExtraLife       int // score awarding the next extra life; 0 when none left
+	extraLifeFlashUntil int
 	fullscreen          bool
 	paused              bool
+	state               GameState
+	stateTicks          int // ticks spent in the current state
 	quit                bool
 	scale               float64
 	tickCounter         int
 	frightenedUntilTick int
 	ghostEatCombo       int
+	opts                Options
+	difficulty          Difficulty
+	brains              map[entities.GhostKind]GhostBrain
+	frightRNG           *rand.Rand // drives frightened turns; reseeded each life
+	brainRNG            *rand.Rand // random source ghost brains see; reseeded each life
+	fruitRNG            *rand.Rand // times how long fruit stays out; reseeded each life
+	level               int
+	modePhase           int // index into the level's scatter/chase schedule
+	modeTicks           int // ticks spent in the current schedule phase
+	lastPelletTick      int // drives the ghost house fallback release timer
+	houseGlobalDots     int // global house dot counter after a life is lost; -1 when off
+	pelletsEaten        int // pellets eaten on the current board, for fruit
+	fruitUntilTick      int // the bonus fruit is showing until this tick
+	fruitsCollected     []entities.FruitKind
+	popups              []popup
+	hitStopUntilTick    int             // play is frozen after eating a ghost until this tick
+	hitStopGhost        *entities.Ghost // the ghost shown as its points during the freeze
+	cutscene            *cutscene.Player
+	attract             attractPhase // attract loop step; attractOff during a real game
+	attractTicks        int
+	demo                bool       // the bot is playing; nothing is saved
+	menu                menuScreen // title screen page
+	menuIndex           int        // highlighted main menu item
+	gameOverIndex       int        // highlighted game over menu item
+	settingsIndex       int        // highlighted settings row
+	botTileX, botTileY  int        // tile the demo bot last decided on
 	audio               *AudioManager
 	easterMessage       string
 	easterUntilTick     int
 	offscreenImage      *ebiten.Image // Cached to avoid per-frame allocation
 }
 
+// New creates a game configured from the environment (see OptionsFromEnv).
 func New() *Game {
+	return NewWithOptions(OptionsFromEnv())
+}
+
+// NewWithOptions creates a game with explicit options.
+func NewWithOptions(opts Options) *Game {
 	rand.Seed(time.Now().UnixNano())
-	m := tm.NewDefaultMap(tileSize)
-	// Start player on a free corridor near bottom center (x=14, y=26 in default maze)
-	startX := float64(14*tileSize + tileSize/2)
-	startY := float64(26*tileSize + tileSize/2)
-	p := &entities.Player{X: startX, Y: startY}
-	g := &Game{tileMap: m, player: p, lives: 3}
+	g := &Game{player: &entities.Player{}, lives: startingLives, level: 1, difficulty: opts.Difficulty, opts: opts}
+	g.nextExtraLife = opts.ExtraLifeScore
+	g.houseGlobalDots = -1
+	g.brains = resolveGhostBrains(opts.GhostBrains)
+	g.resetFrightenedRNG()
+	g.resetBrainRNG()
+	g.resetFruitRNG()
+	g.useMaze(opts.mazeFor(1))
+	m := g.tileMap
 
 	// Load persisted high score (with name if present)
 	if rec := LoadHighScoreRecord(); rec != nil {
@@ -92,18 +144,10 @@ func New() *Game {
 	} else {
 		g.highScore = 0
 	}
-	g.enteringName = true
+	g.attract = attractTitle
 
-	// Spawn 4 ghosts near the center (ghost house area) at nearest corridor tiles
-	spawnTargets := [][2]int{{13, 14}, {14, 14}, {13, 15}, {14, 15}}
-	for _, t := range spawnTargets {
-		ox, oy := g.nearestCorridorTile(t[0], t[1])
-		g.ghosts = append(g.ghosts, &entities.Ghost{
-			X:     float64(ox*tileSize + tileSize/2),
-			Y:     float64(oy*tileSize + tileSize/2),
-			State: entities.GhostNormal,
-		})
-	}
+	g.spawnGhosts()
+	g.placePlayerAtSpawn()
 
 	// Compute initial scale to fit within ~75% of the display area
 	nativeW := m.Width * tileSize
@@ -141,6 +185,10 @@ func (g *Game) Update() error {
 	if g.quit {
 		return ebiten.Termination
 	}
+	if g.attract != attractOff {
+		g.updateAttract()
+		return nil
+	}
 
 	// Clear easter egg message when time elapses
 	if g.easterUntilTick != 0 && g.tickCounter >= g.easterUntilTick {
@@ -149,7 +197,7 @@ func (g *Game) Update() error {
 	}
 
 	// Random, rare easter-egg trigger (about ~100s on average)
-	if !g.enteringName && !g.showingLeaderboard && g.easterMessage == "" {
+	if !g.showingLeaderboard && g.easterMessage == "" {
 		// Roughly 1 in 6000 updates (~100 seconds at 60 UPS)
 		if rand.Intn(easterEggChance) == 0 {
 			if rand.Intn(2) == 0 {
@@ -165,65 +213,37 @@ func (g *Game) Update() error {
 		return nil
 	}
 
-	if g.enteringName {
-		return nil
-	}
-
-	if g.frightenedUntilTick != 0 && g.tickCounter >= g.frightenedUntilTick {
-		g.frightenedUntilTick = 0
-		g.ghostEatCombo = 0
-	}
-	if g.paused {
-		return nil
-	}
-	g.updatePlayerMovement()
-	g.handlePelletCollision()
-	g.updateGhostsRandom()
-	g.checkPlayerGhostCollision()
+	g.step()
 	return nil
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Clear background (black)
-	screen.Fill(color.Black)
+	// Clear background in the maze's colour
+	bg := g.tileMap.Palette.Background
+	screen.Fill(bg)
 
 	// Use cached offscreen image at native resolution then scale up
 	off := g.offscreenImage
-	off.Fill(color.Black) // Clear the cached image
-
-	// Draw map
-	g.tileMap.Draw(off)
+	off.Fill(bg) // Clear the cached image
 
-	// Draw player
-	vector.DrawFilledCircle(off, float32(g.player.X), float32(g.player.Y), float32(tileSize/2-2), color.RGBA{R: 255, G: 221, B: 0, A: 255}, true)
+	if g.attract == attractTitle || g.attract == attractRollCall {
+		g.drawAttract(off)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(g.scale, g.scale)
+		screen.DrawImage(off, op)
+		return
+	}
 
-	// Draw ghosts (simple circles)
-	ghostColors := []color.RGBA{
-		{R: 255, G: 0, B: 0, A: 255},     // red
-		{R: 255, G: 128, B: 255, A: 255}, // pink
-		{R: 255, G: 128, B: 0, A: 255},   // orange
-		{R: 0, G: 191, B: 255, A: 255},   // cyan
-	}
-	for i, gh := range g.ghosts {
-		c := ghostColors[i%len(ghostColors)]
-		if g.isFrightened() {
-			remainingTicks := g.frightenedUntilTick - g.tickCounter
-			// Flash white/blue in last 2 seconds (120 ticks)
-			if remainingTicks < 120 {
-				// Alternate between white and blue every 10 ticks
-				if (g.tickCounter/10)%2 == 0 {
-					c = color.RGBA{R: 255, G: 255, B: 255, A: 255} // white
-				} else {
-					c = color.RGBA{R: 0, G: 0, B: 255, A: 255} // blue
-				}
-			} else {
-				// Solid blue when not flashing
-				c = color.RGBA{R: 0, G: 0, B: 255, A: 255}
-			}
-		}
-		vector.DrawFilledCircle(off, float32(gh.X), float32(gh.Y), float32(tileSize/2-2), c, true)
+	if g.state == StateIntermission {
+		g.drawIntermission(off)
+	} else {
+		g.drawWorld(off)
 	}
 
+	g.drawPopups(off)
+	g.drawFruitIcons(off)
+	g.drawLivesIcons(off)
+
 	// HUD: Score, High Score (with name) & Lives
 	hiLabel := "High"
 	if g.highScoreName != "" {
@@ -246,46 +266,25 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		text.Draw(off, timerText, basicfont.Face7x13, nativeW-textWidth-4, nativeH-4, color.RGBA{R: 0, G: 255, B: 255, A: 255})
 	}
 
-	// If awaiting name, draw prompt centered
-	if g.enteringName {
-		prompt := "Enter name: " + g.playerName + "_"
-		pw := len(prompt) * fontCharWidth
-		nativeW := g.tileMap.Width * tileSize
-		nativeH := g.tileMap.Height * tileSize
-		text.Draw(off, prompt, basicfont.Face7x13, (nativeW-pw)/2, nativeH/2, color.White)
+	// Show the level (bottom left corner)
+	text.Draw(off, fmt.Sprintf("Level %d", g.level), basicfont.Face7x13, 4, g.tileMap.Height*tileSize-4, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+
+	// READY! and GAME OVER banners sit just below the ghost house
+	switch g.state {
+	case StateReady:
+		g.drawBanner(off, "READY!", color.RGBA{R: 255, G: 221, B: 0, A: 255})
+	case StateGameOver:
+		g.drawBanner(off, "GAME OVER", color.RGBA{R: 255, G: 0, B: 0, A: 255})
+	}
+	if g.demo {
+		g.drawDemoBanner(off)
 	}
 
 	// If showing leaderboard, draw it centered
-	if g.showingLeaderboard {
-		list := LoadLeaderboard()
-		nativeW := g.tileMap.Width * tileSize
-		nativeH := g.tileMap.Height * tileSize
-		title := "High Scores"
-		tw := len(title) * fontCharWidth
-		y := nativeH/2 - 40
-		text.Draw(off, title, basicfont.Face7x13, (nativeW-tw)/2, y, color.RGBA{R: 255, G: 215, B: 0, A: 255})
-		y += 14
-
-		// Sort by score descending using efficient sort.Slice
-		sort.Slice(list, func(i, j int) bool {
-			return list[i].Score > list[j].Score
-		})
-
-		// Limit to top 10
-		displayCount := len(list)
-		if displayCount > 10 {
-			displayCount = 10
-		}
-
-		for i := 0; i < displayCount; i++ {
-			line := fmt.Sprintf("%2d. %-12s  %6d", i+1, list[i].Name, list[i].Score)
-			lw := len(line) * fontCharWidth
-			text.Draw(off, line, basicfont.Face7x13, (nativeW-lw)/2, y, color.White)
-			y += 14
-		}
-		hint := "Press Q to exit"
-		hw := len(hint) * fontCharWidth
-		text.Draw(off, hint, basicfont.Face7x13, (nativeW-hw)/2, nativeH-8, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+	if g.gameOverMenuShowing() {
+		g.drawGameOverMenu(off)
+	} else if g.showingLeaderboard {
+		g.drawLeaderboard(off, "Press Q to exit")
 	}
 
 	// Draw easter egg message if present (overlay)
@@ -303,61 +302,132 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	screen.DrawImage(off, op)
 }
 
-func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
-	return g.ScreenWidth(), g.ScreenHeight()
+// ghostColors are the body colours of the four ghosts.
+var ghostColors = map[entities.GhostKind]color.RGBA{
+	entities.GhostRed:    {R: 255, G: 0, B: 0, A: 255},
+	entities.GhostPink:   {R: 255, G: 128, B: 255, A: 255},
+	entities.GhostCyan:   {R: 0, G: 191, B: 255, A: 255},
+	entities.GhostOrange: {R: 255, G: 128, B: 0, A: 255},
 }
 
-func (g *Game) handleInput() {
-	// Name entry handling takes precedence
-	if g.enteringName {
-		// Collect typed characters
-		var chars []rune
-		chars = ebiten.AppendInputChars(chars)
-		for _, r := range chars {
-			if len([]rune(g.playerName)) >= maxPlayerNameLength {
-				break
-			}
-			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == ' ' || r == '_' || r == '-' {
-				g.playerName += string(r)
-			}
-		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
-			rs := []rune(g.playerName)
-			if len(rs) > 0 {
-				g.playerName = string(rs[:len(rs)-1])
-			}
+// drawWorld draws the maze, fruit, player and ghosts.
+func (g *Game) drawWorld(off *ebiten.Image) {
+	// Draw map, flashing the walls once the board is cleared
+	if g.state == StateLevelComplete && (g.stateTicks/levelFlashTicks)%2 == 1 {
+		g.tileMap.DrawWithWallColor(off, color.White)
+	} else {
+		g.tileMap.Draw(off)
+	}
+
+	// Draw bonus fruit
+	if g.fruitActive() && !g.ghostsHidden() {
+		fx, fy := g.fruitPosition()
+		drawFruit(off, fruitFor(g.level).kind, float32(fx), float32(fy))
+	}
+
+	// Draw player, hidden behind the points while frozen after eating a ghost
+	if !g.hitStopActive() {
+		g.drawPlayer(off)
+	}
+
+	// Draw ghosts (simple circles)
+	for _, gh := range g.ghosts {
+		if g.ghostsHidden() {
+			break
 		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyKPEnter) {
-			if len([]rune(g.playerName)) > 0 {
-				g.enteringName = false
-				// Name-based easter eggs
-				low := strings.ToLower(strings.TrimSpace(g.playerName))
-				if low == "rekha" {
-					g.easterMessage = "Dad Loves Rekha"
-					g.easterUntilTick = g.tickCounter + updatesPerSecond*easterEggDuration
-				}
-				if low == "roy" {
-					g.easterMessage = "Dad Loves Roy"
-					g.easterUntilTick = g.tickCounter + updatesPerSecond*easterEggDuration
-				}
-			}
+		if g.hitStopActive() && gh == g.hitStopGhost {
+			continue // drawn as its points popup
 		}
-		// Allow quitting/fullscreen even while entering name
-		if inpututil.IsKeyJustPressed(ebiten.KeyF) {
-			g.fullscreen = !g.fullscreen
-			ebiten.SetFullscreen(g.fullscreen)
+		if gh.State.IsEyes() {
+			drawGhostEyes(off, gh)
+			continue
 		}
-		if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
-			// Save if necessary with name
-			if g.score > g.highScore {
-				g.highScore = g.score
-				_ = SaveHighScoreRecord(&HighScoreRecord{Name: g.playerName, Score: g.highScore})
+		c := ghostColors[gh.Kind]
+		if gh.State == entities.GhostFrightened {
+			remainingTicks := g.frightenedUntilTick - g.tickCounter
+			// Flash white/blue in last 2 seconds (120 ticks)
+			if remainingTicks < 120 {
+				// Alternate between white and blue every 10 ticks
+				if (g.tickCounter/10)%2 == 0 {
+					c = color.RGBA{R: 255, G: 255, B: 255, A: 255} // white
+				} else {
+					c = color.RGBA{R: 0, G: 0, B: 255, A: 255} // blue
+				}
+			} else {
+				// Solid blue when not flashing
+				c = color.RGBA{R: 0, G: 0, B: 255, A: 255}
 			}
-			g.quit = true
 		}
-		return
+		vector.DrawFilledCircle(off, float32(gh.X), float32(gh.Y), float32(tileSize/2-2), c, true)
+		drawGhostEyes(off, gh)
+	}
+}
+
+// drawLeaderboard draws the top ten scores centered, with a hint line below.
+func (g *Game) drawLeaderboard(off *ebiten.Image, hint string) {
+	list := LoadLeaderboard()
+	nativeW := g.tileMap.Width * tileSize
+	nativeH := g.tileMap.Height * tileSize
+	title := "High Scores"
+	tw := len(title) * fontCharWidth
+	y := nativeH/2 - 40
+	text.Draw(off, title, basicfont.Face7x13, (nativeW-tw)/2, y, color.RGBA{R: 255, G: 215, B: 0, A: 255})
+	y += 14
+
+	// Sort by score descending using efficient sort.Slice
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Score > list[j].Score
+	})
+
+	// Limit to top 10
+	displayCount := len(list)
+	if displayCount > 10 {
+		displayCount = 10
+	}
+
+	for i := 0; i < displayCount; i++ {
+		line := fmt.Sprintf("%2d. %-12s  %6d", i+1, list[i].Name, list[i].Score)
+		lw := len(line) * fontCharWidth
+		text.Draw(off, line, basicfont.Face7x13, (nativeW-lw)/2, y, color.White)
+		y += 14
 	}
+	hw := len(hint) * fontCharWidth
+	text.Draw(off, hint, basicfont.Face7x13, (nativeW-hw)/2, nativeH-8, color.RGBA{R: 128, G: 128, B: 128, A: 255})
+}
+
+// drawBanner draws a centered message on the fruit row below the ghost house.
+func (g *Game) drawBanner(dst *ebiten.Image, msg string, c color.Color) {
+	w := len(msg) * fontCharWidth
+	nativeW := g.tileMap.Width * tileSize
+	text.Draw(dst, msg, basicfont.Face7x13, (nativeW-w)/2, g.tileMap.Layout.Fruit.Y*tileSize+tileSize-3, c)
+}
+
+// drawGhostEyes draws a ghost's eyes looking in its direction of travel.
+// Eaten ghosts are drawn with the eyes alone.
+func drawGhostEyes(dst *ebiten.Image, gh *entities.Ghost) {
+	lx, ly := entities.DirDelta(gh.CurrentDir)
+	for _, side := range []float32{-3, 3} {
+		ex := float32(gh.X) + side
+		ey := float32(gh.Y) - 2
+		vector.DrawFilledCircle(dst, ex, ey, 2.5, color.White, true)
+		vector.DrawFilledCircle(dst, ex+float32(lx), ey+float32(ly), 1.25, color.RGBA{R: 33, G: 33, B: 255, A: 255}, true)
+	}
+}
 
+func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	return g.ScreenWidth(), g.ScreenHeight()
+}
+
+func (g *Game) handleInput() {
+	// The attract loop has its own keys
+	if g.attract != attractOff {
+		g.handleAttractInput()
+		return
+	}
+	if g.gameOverMenuShowing() {
+		g.handleGameOverInput()
+		return
+	}
 	// Don't process movement input when game is not actively playing
 	if g.showingLeaderboard || g.paused {
 		// Skip movement input but still allow other keys
@@ -380,6 +450,14 @@ func (g *Game) handleInput() {
 		ebiten.SetFullscreen(g.fullscreen)
 	}
 
+	// Enter or Space skips an intermission
+	if g.state == StateIntermission && !g.showingLeaderboard {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+			g.skipIntermission()
+			return
+		}
+	}
+
 	// Pause toggle with Space
 	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
 		g.paused = !g.paused
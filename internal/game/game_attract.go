@@ -0,0 +1,190 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"pacman/internal/entities"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/basicfont"
+)
+
+// attractPhase is the step of the attract loop shown while nobody is playing.
+type attractPhase int
+
+const (
+	attractOff      attractPhase = iota // a real game is on
+	attractTitle                        // title screen and main menu
+	attractRollCall                     // the ghosts introduce themselves
+	attractDemo                         // the bot plays a real game
+)
+
+const (
+	attractTitleTicks    = 5 * updatesPerSecond
+	attractRollCallTicks = 7 * updatesPerSecond
+	rollCallStepTicks    = updatesPerSecond // one ghost per second
+	demoTicks            = 30 * updatesPerSecond
+	demoLives            = 1
+	titleTextScale       = 4
+)
+
+// rollCall lists the characters in the order the roll call introduces them.
+var rollCall = []struct {
+	kind      entities.GhostKind
+	character string
+	nickname  string
+}{
+	{entities.GhostRed, "SHADOW", "BLINKY"},
+	{entities.GhostPink, "SPEEDY", "PINKY"},
+	{entities.GhostCyan, "BASHFUL", "INKY"},
+	{entities.GhostOrange, "POKEY", "CLYDE"},
+}
+
+// titleText is the title, drawn once and scaled up on the title screen.
+var titleText = func() *ebiten.Image {
+	const title = "PAC-MAN"
+	img := ebiten.NewImage(len(title)*fontCharWidth, 13)
+	text.Draw(img, title, basicfont.Face7x13, 0, 10, color.RGBA{R: 255, G: 221, B: 0, A: 255})
+	return img
+}()
+
+func (g *Game) setAttract(p attractPhase) {
+	g.attract = p
+	g.attractTicks = 0
+}
+
+// updateAttract runs one update of the attract loop: the title and roll call
+// screens time out into the next phase, and the demo drives the normal
+// simulation with the bot at the controls.
+func (g *Game) updateAttract() {
+	g.attractTicks++
+	switch g.attract {
+	case attractTitle:
+		// Only an idle main menu gives way to the roll call
+		if g.menu == menuMain && g.attractTicks >= attractTitleTicks {
+			g.setAttract(attractRollCall)
+		}
+	case attractRollCall:
+		if g.attractTicks >= attractRollCallTicks {
+			g.startDemo()
+		}
+	case attractDemo:
+		if g.state == StatePlaying {
+			g.player.DesiredDir = g.botDirection()
+		}
+		g.step()
+		if g.demoOver() {
+			g.endDemo()
+		}
+	}
+}
+
+// demoOver reports whether the demo has run its course: out of time, out of
+// lives or past its first board.
+func (g *Game) demoOver() bool {
+	return g.attractTicks >= demoTicks || g.state == StateGameOver || g.state == StateIntermission || g.level > 1
+}
+
+// handleAttractInput lets any key leave the roll call or demo for the title
+// screen, where the menu takes over.
+func (g *Game) handleAttractInput() {
+	if g.attract == attractTitle {
+		g.handleMenuInput()
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+		g.quit = true
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		g.fullscreen = !g.fullscreen
+		ebiten.SetFullscreen(g.fullscreen)
+		return
+	}
+	if len(inpututil.AppendJustPressedKeys(nil)) == 0 {
+		return
+	}
+	if g.attract == attractDemo {
+		g.endDemo()
+		return
+	}
+	g.setAttract(attractTitle)
+}
+
+// startDemo starts a fresh game played by the bot, with sound off and
+// nothing written to the leaderboard.
+func (g *Game) startDemo() {
+	g.demo = true
+	g.audio.SetMuted(true)
+	g.Reset()
+	g.lives = demoLives
+	g.botTileX, g.botTileY = -1, -1
+	g.setAttract(attractDemo)
+}
+
+// endDemo throws the demo game away and returns to the title screen.
+func (g *Game) endDemo() {
+	g.demo = false
+	g.audio.SetMuted(false)
+	g.Reset()
+	g.setAttract(attractTitle)
+}
+
+// drawAttract draws the title screen with its menu, and the roll call.
+func (g *Game) drawAttract(dst *ebiten.Image) {
+	nativeW := g.tileMap.Width * tileSize
+	nativeH := g.tileMap.Height * tileSize
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(titleTextScale, titleTextScale)
+	op.GeoM.Translate(float64(nativeW-titleText.Bounds().Dx()*titleTextScale)/2, float64(4*tileSize))
+	dst.DrawImage(titleText, op)
+
+	drawCentered(dst, fmt.Sprintf("HIGH SCORE  %d", g.highScore), 8*tileSize, color.White)
+
+	if g.attract == attractTitle {
+		g.drawMenu(dst)
+		return
+	}
+
+	if g.attract == attractRollCall {
+		drawCentered(dst, "CHARACTER / NICKNAME", 11*tileSize, color.White)
+		shown := g.attractTicks/rollCallStepTicks + 1
+		for i, r := range rollCall {
+			if i >= shown {
+				break
+			}
+			y := (13 + 2*i) * tileSize
+			c := ghostColors[r.kind]
+			vector.DrawFilledCircle(dst, float32(6*tileSize), float32(y-4), float32(tileSize/2-2), c, true)
+			text.Draw(dst, fmt.Sprintf("-%s    \"%s\"", r.character, r.nickname), basicfont.Face7x13, 8*tileSize, y, c)
+		}
+		if shown > len(rollCall) {
+			y := (14 + 2*len(rollCall)) * tileSize
+			vector.DrawFilledCircle(dst, float32(11*tileSize), float32(y-4), 2, color.White, true)
+			text.Draw(dst, fmt.Sprintf("%d PTS", pelletPoints), basicfont.Face7x13, 12*tileSize, y, color.White)
+			vector.DrawFilledCircle(dst, float32(11*tileSize), float32(y+tileSize), 5, color.White, true)
+			text.Draw(dst, fmt.Sprintf("%d PTS", powerPelletPoints), basicfont.Face7x13, 12*tileSize, y+tileSize+4, color.White)
+		}
+	}
+
+	// Blink the prompt twice a second
+	if (g.attractTicks/(updatesPerSecond/2))%2 == 0 {
+		drawCentered(dst, "PRESS ANY KEY", nativeH-4*tileSize, color.RGBA{R: 255, G: 221, B: 0, A: 255})
+	}
+}
+
+// drawDemoBanner marks the demo game as such.
+func (g *Game) drawDemoBanner(dst *ebiten.Image) {
+	drawCentered(dst, "DEMO - PRESS ANY KEY", (g.tileMap.Height-3)*tileSize+tileSize-4, color.RGBA{R: 255, G: 221, B: 0, A: 255})
+}
+
+// drawCentered draws a line of text centered horizontally at baseline y.
+func drawCentered(dst *ebiten.Image, msg string, y int, c color.Color) {
+	w := len(msg) * fontCharWidth
+	text.Draw(dst, msg, basicfont.Face7x13, (dst.Bounds().Dx()-w)/2, y, c)
+}
@@ -0,0 +1,90 @@
+package game
+
+import "testing"
+
+func runAttract(g *Game, n int) {
+	for i := 0; i < n; i++ {
+		g.tickCounter++
+		g.updateAttract()
+	}
+}
+
+func TestAttractLoopCyclesThroughPhases(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	if g.attract != attractTitle {
+		t.Fatalf("a new game should start on the title screen, got phase %d", g.attract)
+	}
+	runAttract(g, attractTitleTicks)
+	if g.attract != attractRollCall {
+		t.Fatalf("expected the roll call after the title, got phase %d", g.attract)
+	}
+	runAttract(g, attractRollCallTicks)
+	if g.attract != attractDemo || !g.demo {
+		t.Fatalf("expected the demo after the roll call, got phase %d (demo %v)", g.attract, g.demo)
+	}
+	if g.lives != demoLives || g.State() != StateReady {
+		t.Fatalf("demo should start a fresh game: lives=%d state=%v", g.lives, g.State())
+	}
+}
+
+func TestDemoBotPlaysTheRealGame(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.startDemo()
+	pellets := g.tileMap.PelletsRemaining()
+	startX, startY := g.player.X, g.player.Y
+	runAttract(g, readyTicks+5*updatesPerSecond)
+	if g.attract != attractDemo {
+		t.Fatalf("demo ended early after the bot was caught: state %v, lives %d", g.State(), g.lives)
+	}
+	if g.player.X == startX && g.player.Y == startY {
+		t.Fatalf("bot never moved the player")
+	}
+	if g.tileMap.PelletsRemaining() >= pellets || g.score == 0 {
+		t.Fatalf("bot ate nothing: pellets %d -> %d, score %d", pellets, g.tileMap.PelletsRemaining(), g.score)
+	}
+}
+
+func TestDemoEndsAndDoesNotKeepScore(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.highScore = 0
+	g.startDemo()
+	g.addScore(5000)
+	if g.highScore != 0 {
+		t.Fatalf("demo points should not count toward the high score, got %d", g.highScore)
+	}
+	catchPlayer(g)
+	for i := 0; i < lifeLostFreezeTicks+deathTicks+1 && g.attract == attractDemo; i++ {
+		runAttract(g, 1)
+	}
+	if g.attract != attractTitle || g.demo {
+		t.Fatalf("losing the demo's last life should return to the title, got phase %d (demo %v)", g.attract, g.demo)
+	}
+	if g.score != 0 || g.lives != startingLives || g.level != 1 {
+		t.Fatalf("expected a fresh session after the demo: score=%d lives=%d level=%d", g.score, g.lives, g.level)
+	}
+}
+
+func TestDemoTimesOut(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.startDemo()
+	g.attractTicks = demoTicks - 1
+	runAttract(g, 1)
+	if g.attract != attractTitle {
+		t.Fatalf("demo should return to the title after %d ticks, got phase %d", demoTicks, g.attract)
+	}
+}
+
+func TestDemoEndsWhenTheBoardIsCleared(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.startDemo()
+	g.setState(StateLevelComplete)
+	runAttract(g, levelCompleteTicks)
+	if g.attract != attractTitle || g.level != 1 {
+		t.Fatalf("demo should stop after its first board, got phase %d on level %d", g.attract, g.level)
+	}
+}
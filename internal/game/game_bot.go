@@ -0,0 +1,76 @@
+package game
+
+import (
+	"pacman/internal/entities"
+	"pacman/internal/pathfinding"
+	tm "pacman/internal/tilemap"
+)
+
+const (
+	// botDangerRadius is the maze distance (in tiles) at which the demo bot
+	// starts steering away from a ghost.
+	botDangerRadius = 5
+	botDangerWeight = 20
+)
+
+// botDirection picks where the demo bot steers the player: toward the
+// nearest pellet or frightened ghost along the maze, away from ghosts that
+// get too close. It only decides on entering a new tile, so it does not
+// dither between two equally good choices.
+func (g *Game) botDirection() entities.Direction {
+	px, py := g.playerGrid()
+	if px == g.botTileX && py == g.botTileY && g.player.CurrentDir != entities.DirNone {
+		return g.player.DesiredDir
+	}
+	g.botTileX, g.botTileY = px, py
+
+	best := entities.DirNone
+	bestScore := 0
+	for _, d := range ghostDecisionOrder {
+		nx, ny, ok := g.openNeighbour(px, py, d)
+		if !ok {
+			continue
+		}
+		score := g.botScore(pathfinding.NewDistanceField(g.tileMap, pathfinding.Point{X: nx, Y: ny}))
+		if isReverse(g.player.CurrentDir, d) {
+			score++ // keep going on ties
+		}
+		if best == entities.DirNone || score < bestScore {
+			best, bestScore = d, score
+		}
+	}
+	return best
+}
+
+// botScore rates standing on the target tile of field; lower is better.
+func (g *Game) botScore(field *pathfinding.DistanceField) int {
+	far := g.tileMap.Width * g.tileMap.Height
+	goal := far
+	for y := 0; y < g.tileMap.Height; y++ {
+		for x := 0; x < g.tileMap.Width; x++ {
+			if t := g.tileMap.Tiles[y][x]; t != tm.TilePellet && t != tm.TilePower {
+				continue
+			}
+			if d := field.Distance(x, y); d != pathfinding.Unreachable && d < goal {
+				goal = d
+			}
+		}
+	}
+	danger := 0
+	for _, gh := range g.ghosts {
+		gx, gy := g.ghostGrid(gh)
+		d := field.Distance(gx, gy)
+		if d == pathfinding.Unreachable {
+			continue
+		}
+		switch {
+		case gh.State == entities.GhostFrightened:
+			if d < goal {
+				goal = d
+			}
+		case gh.State.IsRoaming() && d <= botDangerRadius:
+			danger += (botDangerRadius + 1 - d) * botDangerWeight
+		}
+	}
+	return goal + danger
+}
@@ -8,29 +8,40 @@ func (g *Game) handlePelletCollision() {
 	if g.isNearCellCenter() {
 		ate, power := g.tileMap.EatPelletAt(gx, gy)
 		if ate {
+			g.countHouseDot()
+			g.countFruitPellet()
+			g.updateElroy()
 			if power {
-				g.score += powerPelletPoints
-				// Enter frightened mode for standard duration
-				g.frightenedUntilTick = g.tickCounter + frightenedDurationUpdates
-				g.ghostEatCombo = 0
-				// Reverse all ghosts when entering frightened mode
-				g.reverseAllGhosts()
+				g.addScore(powerPelletPoints)
+				// Enter frightened mode for this level's duration
+				g.frightenGhosts(g.frightenedDuration())
 				if g.audio != nil {
 					g.audio.PlayPowerPellet()
 				}
 			} else {
-				g.score += pelletPoints
+				g.addScore(pelletPoints)
 				if g.audio != nil {
 					g.audio.PlayPellet()
 				}
 			}
-			// Update and persist high score if surpassed
-			if g.score > g.highScore {
-				g.highScore = g.score
-				_ = SaveHighScoreRecord(&HighScoreRecord{Name: g.playerName, Score: g.highScore})
+			if g.tileMap.PelletsRemaining() == 0 {
+				g.setState(StateLevelComplete)
+				return
 			}
 		}
 	}
+	g.eatFruit()
+}
+
+// addScore adds points to the score, awards extra lives and persists the
+// high score if it was surpassed outside the demo.
+func (g *Game) addScore(points int) {
+	g.score += points
+	g.checkExtraLife()
+	if g.score > g.highScore && !g.demo {
+		g.highScore = g.score
+		_ = SaveHighScoreRecord(&HighScoreRecord{Name: g.playerName, Score: g.highScore})
+	}
 }
 
 func (g *Game) checkPlayerGhostCollision() {
@@ -38,10 +49,14 @@ func (g *Game) checkPlayerGhostCollision() {
 	pr := float64(tileSize/2 - 2)
 	gr := float64(tileSize/2 - 2)
 	for _, gh := range g.ghosts {
+		// Eyes on their way home are harmless and cannot be eaten again
+		if gh.State.IsEyes() {
+			continue
+		}
 		dx := g.player.X - gh.X
 		dy := g.player.Y - gh.Y
 		if dx*dx+dy*dy <= (pr+gr)*(pr+gr) {
-			if g.isFrightened() {
+			if gh.State == entities.GhostFrightened {
 				// Eat ghost: score increases with combo 200, 400, 800, 1600
 				base := baseGhostPoints
 				if g.ghostEatCombo > 0 {
@@ -50,35 +65,21 @@ func (g *Game) checkPlayerGhostCollision() {
 				if base > maxGhostPoints {
 					base = maxGhostPoints
 				}
-				g.score += base
+				g.addScore(base)
 				if g.audio != nil {
 					g.audio.PlayGhostEaten()
 				}
-				if g.score > g.highScore {
-					g.highScore = g.score
-					_ = SaveHighScoreRecord(&HighScoreRecord{Name: g.playerName, Score: g.highScore})
-				}
 				g.ghostEatCombo++
 				// Mark ghost as eaten: it should return to house quickly (eyes-only behavior)
 				gh.State = entities.GhostEaten
 				// Immediately choose a direction toward the house
 				gh.CurrentDir = entities.DirNone
-				continue
-			}
-			g.lives--
-			if g.audio != nil {
-				g.audio.PlayDeath()
-			}
-			g.resetPositions()
-			if g.lives <= 0 {
-				// Save best on game over
-				if g.score > g.highScore {
-					g.highScore = g.score
-					_ = SaveHighScoreRecord(&HighScoreRecord{Name: g.playerName, Score: g.highScore})
-				}
-				// Show leaderboard instead of continuing
-				g.showingLeaderboard = true
+				// Freeze with the points shown where the ghost was; any other
+				// ghost touching the player is dealt with after the freeze
+				g.startHitStop(gh, base)
+				return
 			}
+			g.loseLife()
 			return
 		}
 	}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// whitePixel is the source image for filled paths.
+var whitePixel = func() *ebiten.Image {
+	img := ebiten.NewImage(3, 3)
+	img.Fill(color.White)
+	return img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+}()
+
+// loseLife takes a life and starts the freeze and death sequence. The state
+// machine then moves on to the next life or the game over banner.
+func (g *Game) loseLife() {
+	g.lives--
+	if g.lives <= 0 && !g.demo {
+		// Save best on game over
+		if g.score > g.highScore {
+			g.highScore = g.score
+			_ = SaveHighScoreRecord(&HighScoreRecord{Name: g.playerName, Score: g.highScore})
+		}
+	}
+	g.setState(StateLifeLost)
+}
+
+// updateDeath runs the LifeLost state: a freeze, then the death animation
+// with its sound, then the READY pause for the next life.
+func (g *Game) updateDeath() {
+	if g.stateTicks == lifeLostFreezeTicks && g.audio != nil {
+		g.audio.PlayDeath()
+	}
+	if g.stateTicks < lifeLostFreezeTicks+deathTicks {
+		return
+	}
+	if g.lives <= 0 {
+		g.setState(StateGameOver)
+		return
+	}
+	g.startNextLife()
+}
+
+// startNextLife puts everyone back at the start after a death. The
+// scatter/chase schedule restarts and the ghost house switches to the global
+// dot counter for the rest of the level.
+func (g *Game) startNextLife() {
+	g.modePhase = 0
+	g.modeTicks = 0
+	g.houseGlobalDots = 0
+	g.resetPositions()
+	g.setState(StateReady)
+}
+
+// ghostsHidden reports whether ghosts and fruit are left out of the frame:
+// once the death animation starts and while the cleared maze flashes.
+func (g *Game) ghostsHidden() bool {
+	switch g.state {
+	case StateLifeLost:
+		return g.stateTicks >= lifeLostFreezeTicks
+	case StateLevelComplete:
+		return true
+	default:
+		return false
+	}
+}
+
+// deathProgress returns how far the death animation has played, from 0 while
+// frozen to 1 once the player has vanished.
+func (g *Game) deathProgress() float64 {
+	if g.state != StateLifeLost || g.stateTicks <= lifeLostFreezeTicks {
+		return 0
+	}
+	p := float64(g.stateTicks-lifeLostFreezeTicks) / float64(deathTicks)
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+// drawPlayer draws Pac-Man. During the death animation the mouth opens from
+// the top until nothing is left.
+func (g *Game) drawPlayer(dst *ebiten.Image) {
+	c := color.RGBA{R: 255, G: 221, B: 0, A: 255}
+	r := float32(tileSize/2 - 2)
+	x, y := float32(g.player.X), float32(g.player.Y)
+	p := g.deathProgress()
+	if p == 0 {
+		vector.DrawFilledCircle(dst, x, y, r, c, true)
+		return
+	}
+	if p >= 1 {
+		return
+	}
+	// Half the mouth opens on each side of straight up
+	half := float32(p * math.Pi)
+	up := float32(-math.Pi / 2)
+	var path vector.Path
+	path.MoveTo(x, y)
+	path.Arc(x, y, r, up+half, up-half+2*math.Pi, vector.Clockwise)
+	path.Close()
+	drawFilledPath(dst, &path, c)
+}
+
+// drawFilledPath fills a vector path with a solid colour.
+func drawFilledPath(dst *ebiten.Image, path *vector.Path, c color.RGBA) {
+	vs, is := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	for i := range vs {
+		vs[i].SrcX, vs[i].SrcY = 1, 1
+		vs[i].ColorR = float32(c.R) / 255
+		vs[i].ColorG = float32(c.G) / 255
+		vs[i].ColorB = float32(c.B) / 255
+		vs[i].ColorA = float32(c.A) / 255
+	}
+	dst.DrawTriangles(vs, is, whitePixel, &ebiten.DrawTrianglesOptions{AntiAlias: true})
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+// catchPlayer puts the red ghost on the player while playing.
+func catchPlayer(g *Game) {
+	red := g.ghostOfKind(entities.GhostRed)
+	red.State = entities.GhostChase
+	red.X, red.Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+}
+
+func TestDeathSequenceHidesGhostsAfterFreeze(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	advance(g, readyTicks)
+	catchPlayer(g)
+	advance(g, lifeLostFreezeTicks-1)
+	if g.ghostsHidden() || g.deathProgress() != 0 {
+		t.Fatal("ghosts should stay in view while frozen")
+	}
+	advance(g, deathTicks/2)
+	if !g.ghostsHidden() {
+		t.Fatal("ghosts should be hidden during the death animation")
+	}
+	if p := g.deathProgress(); p <= 0 || p >= 1 {
+		t.Fatalf("death animation should be part way through, got %.2f", p)
+	}
+}
+
+func TestNextLifeResetsGhostsAndSchedule(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	advance(g, readyTicks)
+	g.modePhase, g.modeTicks = 3, 42
+	cyan := g.ghostOfKind(entities.GhostCyan)
+	cyan.State = entities.GhostFrightened
+	g.frightenedUntilTick = g.tickCounter + 100
+	catchPlayer(g)
+	advance(g, lifeLostFreezeTicks+deathTicks)
+
+	if g.State() != StateReady {
+		t.Fatalf("expected Ready for the next life, got %v", g.State())
+	}
+	if g.modePhase != 0 || g.modeTicks != 0 {
+		t.Fatalf("scatter/chase schedule should restart, got phase %d tick %d", g.modePhase, g.modeTicks)
+	}
+	if g.isFrightened() {
+		t.Fatal("frightened mode should end with the life")
+	}
+	for _, gh := range g.ghosts {
+		want := entities.GhostInHouse
+		if gh.Kind == entities.GhostRed {
+			want = entities.GhostScatter
+		}
+		if gh.State != want {
+			t.Fatalf("ghost %v should respawn as %v, got %v", gh.Kind, want, gh.State)
+		}
+	}
+	if g.houseGlobalDots != 0 {
+		t.Fatalf("house should switch to the global dot counter, got %d", g.houseGlobalDots)
+	}
+}
+
+func TestGlobalDotCounterReleasesGhosts(t *testing.T) {
+	g := New()
+	g.startNextLife()
+	pink := g.ghostOfKind(entities.GhostPink)
+	cyan := g.ghostOfKind(entities.GhostCyan)
+	orange := g.ghostOfKind(entities.GhostOrange)
+
+	for _, gh := range []*entities.Ghost{pink, cyan} {
+		limit := houseGlobalDotLimits[gh.Kind]
+		for g.houseGlobalDots < limit-1 {
+			g.countHouseDot()
+			g.updateGhostHouse()
+		}
+		if gh.State != entities.GhostInHouse {
+			t.Fatalf("%v should wait for %d global dots, got %v", gh.Kind, limit, gh.State)
+		}
+		g.countHouseDot()
+		g.updateGhostHouse()
+		if gh.State != entities.GhostLeaving {
+			t.Fatalf("%v should leave at %d global dots, got %v", gh.Kind, limit, gh.State)
+		}
+	}
+	if orange.DotCounter != 0 {
+		t.Fatalf("personal counters should not run with the global one, orange has %d", orange.DotCounter)
+	}
+	for g.houseGlobalDots < houseGlobalOrangeLimit {
+		g.countHouseDot()
+	}
+	g.updateGhostHouse()
+	if g.houseGlobalDots != -1 {
+		t.Fatal("global counter should switch off with orange still inside")
+	}
+	if orange.State != entities.GhostInHouse {
+		t.Fatalf("orange goes back to its personal counter, got %v", orange.State)
+	}
+}
@@ -0,0 +1,43 @@
+package game
+
+import "pacman/internal/entities"
+
+// elroyStage returns the red ghost's Cruise Elroy stage: 0 while off, then 1
+// and 2 as the pellets left drop to the level's thresholds.
+func (g *Game) elroyStage() int {
+	left := g.tileMap.PelletsRemaining()
+	dots := levelFor(g.level).elroyDots
+	switch {
+	case left <= dots[1]:
+		return 2
+	case left <= dots[0]:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// elroySpeed returns the speed multiplier for a ghost from its Cruise Elroy
+// stage. Only the red ghost in the maze is affected; frightened ghosts and
+// eyes keep their own speeds.
+func (g *Game) elroySpeed(gh *entities.Ghost) float64 {
+	if gh.Kind != entities.GhostRed || !gh.State.IsRoaming() {
+		return 1
+	}
+	stage := g.elroyStage()
+	if stage == 0 {
+		return 1
+	}
+	return levelFor(g.level).elroySpeed[stage-1]
+}
+
+// updateElroy switches a scattering red ghost to chase as soon as Cruise
+// Elroy starts, without waiting for the next mode change.
+func (g *Game) updateElroy() {
+	if g.elroyStage() == 0 {
+		return
+	}
+	if gh := g.ghostOfKind(entities.GhostRed); gh != nil && gh.State == entities.GhostScatter {
+		gh.State = entities.GhostChase
+	}
+}
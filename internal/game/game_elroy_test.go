@@ -0,0 +1,76 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+// eatPelletsUntil clears pellets from the board until only left remain.
+func eatPelletsUntil(g *Game, left int) {
+	for y := 0; y < g.tileMap.Height; y++ {
+		for x := 0; x < g.tileMap.Width; x++ {
+			if g.tileMap.PelletsRemaining() <= left {
+				return
+			}
+			g.tileMap.EatPelletAt(x, y)
+		}
+	}
+}
+
+func TestElroyStagesFollowLevelThresholds(t *testing.T) {
+	g := New()
+	dots := levelFor(1).elroyDots
+	if s := g.elroyStage(); s != 0 {
+		t.Fatalf("elroy should be off on a full board, got stage %d", s)
+	}
+	eatPelletsUntil(g, dots[0]+1)
+	if s := g.elroyStage(); s != 0 {
+		t.Fatalf("elroy should wait for the threshold, got stage %d", s)
+	}
+	eatPelletsUntil(g, dots[0])
+	if s := g.elroyStage(); s != 1 {
+		t.Fatalf("expected stage 1 at %d pellets, got %d", dots[0], s)
+	}
+	eatPelletsUntil(g, dots[1])
+	if s := g.elroyStage(); s != 2 {
+		t.Fatalf("expected stage 2 at %d pellets, got %d", dots[1], s)
+	}
+}
+
+func TestElroySpeedsUpOnlyTheRedGhost(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	pink := g.ghostOfKind(entities.GhostPink)
+	pink.State = entities.GhostChase
+	eatPelletsUntil(g, levelFor(1).elroyDots[1])
+	if got, want := g.elroySpeed(red), levelFor(1).elroySpeed[1]; got != want {
+		t.Fatalf("red ghost speed multiplier %.2f, want %.2f", got, want)
+	}
+	if got := g.elroySpeed(pink); got != 1 {
+		t.Fatalf("other ghosts should keep their speed, got %.2f", got)
+	}
+	red.State = entities.GhostFrightened
+	if got := g.elroySpeed(red); got != 1 {
+		t.Fatalf("frightened red ghost should not speed up, got %.2f", got)
+	}
+}
+
+func TestElroyKeepsChasingThroughScatter(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	if red.State != entities.GhostScatter {
+		t.Fatalf("red ghost should start in scatter, got %v", red.State)
+	}
+	eatPelletsUntil(g, levelFor(1).elroyDots[0])
+	g.updateElroy()
+	if red.State != entities.GhostChase {
+		t.Fatalf("elroy should switch the red ghost to chase, got %v", red.State)
+	}
+	if s := g.roamingState(entities.GhostRed); s != entities.GhostChase {
+		t.Fatalf("red ghost should come back from frightened chasing, got %v", s)
+	}
+	if s := g.roamingState(entities.GhostPink); s != entities.GhostScatter {
+		t.Fatalf("other ghosts should still scatter, got %v", s)
+	}
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"math/rand"
+
+	"pacman/internal/entities"
+	"pacman/internal/pathfinding"
+)
+
+// frightenedSeed seeds the frightened-mode RNG at the start of every life
+// and level, so the same play produces the same ghost turns.
+const frightenedSeed = 0x5eed
+
+// frightenedPolicy decides how frightened ghosts choose their turns.
+type frightenedPolicy int
+
+const (
+	// frightenedRandom picks a pseudo-random direction at each intersection,
+	// like the arcade.
+	frightenedRandom frightenedPolicy = iota
+	// frightenedFlee heads for the exit that is furthest from the player by
+	// walking distance.
+	frightenedFlee
+)
+
+// frightenedDuration is how long a power pellet frightens the ghosts on the
+// current level and difficulty.
+func (g *Game) frightenedDuration() int {
+	return int(float64(levelFor(g.level).frightenedTicks) * difficultyTable[g.difficulty].frightenedScale)
+}
+
+func (g *Game) resetFrightenedRNG() {
+	g.frightRNG = rand.New(rand.NewSource(frightenedSeed))
+}
+
+// frightenedDirection picks a frightened ghost's next direction according to
+// the difficulty's policy. Frightened ghosts never reverse by choice.
+func (g *Game) frightenedDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
+	if difficultyTable[g.difficulty].frightened == frightenedFlee {
+		return g.fleeDirection(gh, gx, gy)
+	}
+	return g.randomTurnDirection(gh, gx, gy)
+}
+
+// randomTurnDirection draws a random starting direction and, if that way is
+// blocked, tries the others in up, left, down, right order.
+func (g *Game) randomTurnDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
+	start := g.frightRNG.Intn(len(ghostDecisionOrder))
+	for i := range ghostDecisionOrder {
+		d := ghostDecisionOrder[(start+i)%len(ghostDecisionOrder)]
+		if isReverse(gh.CurrentDir, d) {
+			continue
+		}
+		if _, _, ok := g.openNeighbour(gx, gy, d); ok {
+			return d
+		}
+	}
+	return g.deadEndDirection(gh, gx, gy)
+}
+
+// fleeDirection picks the exit whose tile is furthest from the player along
+// the maze, rather than in a straight line.
+func (g *Game) fleeDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
+	px, py := g.playerGrid()
+	field := g.paths.Field(pathfinding.Point{X: px, Y: py})
+	best := entities.DirNone
+	bestDist := -1
+	for _, d := range ghostDecisionOrder {
+		if isReverse(gh.CurrentDir, d) {
+			continue
+		}
+		nx, ny, ok := g.openNeighbour(gx, gy, d)
+		if !ok {
+			continue
+		}
+		dist := field.Distance(nx, ny)
+		if dist == pathfinding.Unreachable {
+			dist = g.tileMap.Width * g.tileMap.Height
+		}
+		if dist > bestDist {
+			best = d
+			bestDist = dist
+		}
+	}
+	if best != entities.DirNone {
+		return best
+	}
+	return g.deadEndDirection(gh, gx, gy)
+}
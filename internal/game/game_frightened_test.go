@@ -0,0 +1,98 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+	"pacman/internal/pathfinding"
+)
+
+func TestFrightenedRandomTurnsAreSeeded(t *testing.T) {
+	a, b := New(), New()
+	for i := 0; i < 50; i++ {
+		ga, gb := a.ghosts[0], b.ghosts[0]
+		placeGhost(a, ga, 6, 5, entities.DirUp)
+		placeGhost(b, gb, 6, 5, entities.DirUp)
+		da := a.randomTurnDirection(ga, 6, 5)
+		db := b.randomTurnDirection(gb, 6, 5)
+		if da != db {
+			t.Fatalf("turn %d differs between identically seeded games: %v vs %v", i, da, db)
+		}
+		if da == entities.DirDown {
+			t.Fatalf("frightened ghost reversed on turn %d", i)
+		}
+	}
+}
+
+func TestFrightenedRandomTurnsVary(t *testing.T) {
+	g := New()
+	gh := g.ghosts[0]
+	seen := map[entities.Direction]bool{}
+	// (6,5) is a crossroads: up, left, right and down are all open.
+	for i := 0; i < 100; i++ {
+		placeGhost(g, gh, 6, 5, entities.DirUp)
+		seen[g.randomTurnDirection(gh, 6, 5)] = true
+	}
+	if len(seen) < 2 {
+		t.Fatalf("expected frightened ghosts to pick different exits, only saw %v", seen)
+	}
+}
+
+func TestDifficultyZeroValueIsNormal(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	if g := NewWithOptions(Options{}); g.difficulty != DifficultyNormal {
+		t.Fatalf("expected an unset difficulty to play normal, got %v", g.difficulty)
+	}
+}
+
+func TestEasyFrightensLonger(t *testing.T) {
+	g := New()
+	g.difficulty = DifficultyNormal
+	normal := g.frightenedDuration()
+	if normal != levelFor(1).frightenedTicks {
+		t.Fatalf("normal should use the level's frightened time, got %d", normal)
+	}
+	g.difficulty = DifficultyEasy
+	if easy := g.frightenedDuration(); easy <= normal {
+		t.Fatalf("easy should frighten ghosts longer than normal, got %d vs %d", easy, normal)
+	}
+}
+
+func TestFleePolicyMaximizesWalkingDistance(t *testing.T) {
+	g := New()
+	g.difficulty = DifficultyHard
+	placePlayer(g, 9, 5, entities.DirNone)
+	gh := g.ghosts[0]
+	placeGhost(g, gh, 6, 5, entities.DirUp)
+	d := g.frightenedDirection(gh, 6, 5)
+	field := g.paths.Field(pathfinding.Point{X: 9, Y: 5})
+	nx, ny, _ := g.openNeighbour(6, 5, d)
+	for _, other := range []entities.Direction{entities.DirUp, entities.DirLeft, entities.DirRight} {
+		ox, oy, ok := g.openNeighbour(6, 5, other)
+		if ok && field.Distance(ox, oy) > field.Distance(nx, ny) {
+			t.Fatalf("flee chose %v but %v leads further from the player", d, other)
+		}
+	}
+	if d == entities.DirRight {
+		t.Fatal("fleeing ghost should not head toward the player")
+	}
+}
+
+func TestParseDifficulty(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Difficulty
+		ok   bool
+	}{
+		{"easy", DifficultyEasy, true},
+		{" Hard ", DifficultyHard, true},
+		{"", DifficultyNormal, true},
+		{"nightmare", DifficultyNormal, false},
+	}
+	for _, tc := range tests {
+		got, ok := ParseDifficulty(tc.in)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("ParseDifficulty(%q) = %v,%v want %v,%v", tc.in, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"math/rand"
+
+	"pacman/internal/entities"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	fruitMinTicks   = 9 * updatesPerSecond // fruit stays between 9 and 10 seconds
+	fruitExtraTicks = updatesPerSecond
+	fruitPopupTicks = 2 * updatesPerSecond
+	maxFruitIcons   = 7 // recently collected fruit shown in the HUD
+)
+
+// fruitSeed seeds the fruit RNG at the start of every life and level, so
+// the same play keeps the fruit out for the same time.
+const fruitSeed = 0xf2017
+
+// fruitPelletCounts are the pellets eaten on a level at which a fruit appears.
+var fruitPelletCounts = []int{70, 170}
+
+type fruitSpec struct {
+	kind   entities.FruitKind
+	points int
+}
+
+// fruitTable is indexed by level-1; levels past the end reuse the last entry.
+var fruitTable = []fruitSpec{
+	{entities.FruitCherry, 100},
+	{entities.FruitStrawberry, 300},
+	{entities.FruitOrange, 500},
+	{entities.FruitOrange, 500},
+	{entities.FruitApple, 700},
+	{entities.FruitApple, 700},
+	{entities.FruitMelon, 1000},
+	{entities.FruitMelon, 1000},
+	{entities.FruitGalaxian, 2000},
+	{entities.FruitGalaxian, 2000},
+	{entities.FruitBell, 3000},
+	{entities.FruitBell, 3000},
+	{entities.FruitKey, 5000},
+}
+
+var fruitColors = map[entities.FruitKind]color.RGBA{
+	entities.FruitCherry:     {R: 222, G: 0, B: 0, A: 255},
+	entities.FruitStrawberry: {R: 255, G: 64, B: 96, A: 255},
+	entities.FruitOrange:     {R: 255, G: 160, B: 0, A: 255},
+	entities.FruitApple:      {R: 200, G: 16, B: 16, A: 255},
+	entities.FruitMelon:      {R: 64, G: 200, B: 64, A: 255},
+	entities.FruitGalaxian:   {R: 255, G: 255, B: 0, A: 255},
+	entities.FruitBell:       {R: 255, G: 220, B: 64, A: 255},
+	entities.FruitKey:        {R: 0, G: 220, B: 255, A: 255},
+}
+
+// fruitFor returns the fruit for a 1-based level number.
+func fruitFor(level int) fruitSpec {
+	i := level - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(fruitTable) {
+		i = len(fruitTable) - 1
+	}
+	return fruitTable[i]
+}
+
+func (g *Game) resetFruitRNG() {
+	g.fruitRNG = rand.New(rand.NewSource(fruitSeed))
+}
+
+func (g *Game) fruitActive() bool {
+	return g.fruitUntilTick > g.tickCounter
+}
+
+func (g *Game) fruitPosition() (float64, float64) {
+	f := g.tileMap.Layout.Fruit
+	return g.cellCenter(f.X, f.Y)
+}
+
+// countFruitPellet spawns the level's fruit when the pellets eaten on this
+// board reach one of the fruit counts.
+func (g *Game) countFruitPellet() {
+	g.pelletsEaten++
+	for _, n := range fruitPelletCounts {
+		if g.pelletsEaten == n {
+			g.fruitUntilTick = g.tickCounter + fruitMinTicks + g.fruitRNG.Intn(fruitExtraTicks)
+		}
+	}
+}
+
+// eatFruit scores the fruit when the player touches it.
+func (g *Game) eatFruit() {
+	if !g.fruitActive() {
+		return
+	}
+	fx, fy := g.fruitPosition()
+	dx, dy := g.player.X-fx, g.player.Y-fy
+	if dx*dx+dy*dy > float64(tileSize*tileSize)/4 {
+		return
+	}
+	g.fruitUntilTick = 0
+	spec := fruitFor(g.level)
+	g.addScore(spec.points)
+	g.addPopup(fmt.Sprint(spec.points), fx, fy, color.RGBA{R: 255, G: 184, B: 255, A: 255}, fruitPopupTicks)
+	g.fruitsCollected = append(g.fruitsCollected, spec.kind)
+	if len(g.fruitsCollected) > maxFruitIcons {
+		g.fruitsCollected = g.fruitsCollected[len(g.fruitsCollected)-maxFruitIcons:]
+	}
+	if g.audio != nil {
+		g.audio.PlayFruit()
+	}
+}
+
+// drawFruit draws a fruit icon centered on (x, y).
+func drawFruit(dst *ebiten.Image, kind entities.FruitKind, x, y float32) {
+	r := float32(tileSize/2 - 3)
+	vector.DrawFilledCircle(dst, x, y+1, r, fruitColors[kind], true)
+	vector.StrokeLine(dst, x, y+1-r, x+2, y-r-2, 1.5, color.RGBA{R: 0, G: 160, B: 0, A: 255}, true)
+}
+
+// drawFruitIcons lists recently collected fruit along the bottom right.
+func (g *Game) drawFruitIcons(dst *ebiten.Image) {
+	nativeW := g.tileMap.Width * tileSize
+	y := float32((g.tileMap.Height-2)*tileSize + tileSize/2)
+	for i, kind := range g.fruitsCollected {
+		x := float32(nativeW - (len(g.fruitsCollected)-i)*tileSize)
+		drawFruit(dst, kind, x, y)
+	}
+}
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+// eatPellets runs n pellets through the normal collision path.
+func eatPellets(g *Game, n int) {
+	for y := 0; y < g.tileMap.Height && n > 0; y++ {
+		for x := 0; x < g.tileMap.Width && n > 0; x++ {
+			if ate, _ := g.tileMap.EatPelletAt(x, y); ate {
+				g.countFruitPellet()
+				n--
+			}
+		}
+	}
+}
+
+func TestFruitAppearsAfterPelletCounts(t *testing.T) {
+	g := New()
+	eatPellets(g, fruitPelletCounts[0]-1)
+	if g.fruitActive() {
+		t.Fatal("fruit should not appear early")
+	}
+	eatPellets(g, 1)
+	if !g.fruitActive() {
+		t.Fatalf("fruit should appear after %d pellets", fruitPelletCounts[0])
+	}
+	left := g.fruitUntilTick - g.tickCounter
+	if left < fruitMinTicks || left >= fruitMinTicks+fruitExtraTicks {
+		t.Fatalf("fruit should last 9-10 seconds, got %d ticks", left)
+	}
+	g.tickCounter = g.fruitUntilTick
+	if g.fruitActive() {
+		t.Fatal("fruit should disappear when its time is up")
+	}
+	eatPellets(g, fruitPelletCounts[1]-fruitPelletCounts[0])
+	if !g.fruitActive() {
+		t.Fatalf("second fruit should appear after %d pellets", fruitPelletCounts[1])
+	}
+}
+
+func TestEatingFruitScoresLevelPoints(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	g.level = 3
+	g.fruitUntilTick = g.tickCounter + fruitMinTicks
+	f := g.tileMap.Layout.Fruit
+	placePlayer(g, f.X, f.Y, entities.DirNone)
+	g.handlePelletCollision()
+	if g.score != 500 {
+		t.Fatalf("level 3 fruit should score 500, got %d", g.score)
+	}
+	if g.fruitActive() {
+		t.Fatal("eaten fruit should disappear")
+	}
+	if len(g.popups) != 1 || g.popups[0].text != "500" {
+		t.Fatalf("expected a 500 popup, got %+v", g.popups)
+	}
+	if len(g.fruitsCollected) != 1 || g.fruitsCollected[0] != entities.FruitOrange {
+		t.Fatalf("expected an orange in the HUD, got %v", g.fruitsCollected)
+	}
+	g.tickCounter += fruitPopupTicks
+	g.updatePopups()
+	if len(g.popups) != 0 {
+		t.Fatal("popup should expire")
+	}
+}
+
+func TestFruitTable(t *testing.T) {
+	if f := fruitFor(1); f.kind != entities.FruitCherry || f.points != 100 {
+		t.Fatalf("level 1 should be a 100 point cherry, got %v %d", f.kind, f.points)
+	}
+	if f := fruitFor(99); f.kind != entities.FruitKey || f.points != 5000 {
+		t.Fatalf("late levels should be a 5000 point key, got %v %d", f.kind, f.points)
+	}
+}
+
+func TestFruitIconsAreCapped(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	f := g.tileMap.Layout.Fruit
+	placePlayer(g, f.X, f.Y, entities.DirNone)
+	for i := 0; i < maxFruitIcons+3; i++ {
+		g.fruitUntilTick = g.tickCounter + 1
+		g.eatFruit()
+	}
+	if len(g.fruitsCollected) != maxFruitIcons {
+		t.Fatalf("expected %d fruit icons, got %d", maxFruitIcons, len(g.fruitsCollected))
+	}
+}
+
+func TestPauseHoldsFruit(t *testing.T) {
+	g := New()
+	eatPellets(g, fruitPelletCounts[0])
+	left := g.fruitUntilTick - g.tickCounter
+	g.paused = true
+	advance(g, fruitMinTicks+fruitExtraTicks)
+	g.paused = false
+	if got := g.fruitUntilTick - g.tickCounter; got != left {
+		t.Fatalf("fruit should have %d ticks left after a pause, got %d", left, got)
+	}
+}
+
+func TestFruitTimeIsPerGame(t *testing.T) {
+	a, b := New(), New()
+	eatPellets(a, fruitPelletCounts[0])
+	eatPellets(b, fruitPelletCounts[0])
+	if a.fruitUntilTick != b.fruitUntilTick {
+		t.Fatalf("the same play should keep fruit out the same time, got %d and %d", a.fruitUntilTick, b.fruitUntilTick)
+	}
+}
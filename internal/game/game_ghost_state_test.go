@@ -0,0 +1,79 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func TestPowerPelletFrightensOnlyRoamingGhosts(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	pink := g.ghostOfKind(entities.GhostPink)
+	g.frightenGhosts(frightenedDurationUpdates)
+	if red.State != entities.GhostFrightened {
+		t.Fatalf("roaming ghost should be frightened, got %v", red.State)
+	}
+	if pink.State != entities.GhostInHouse {
+		t.Fatalf("ghost in the house should stay put, got %v", pink.State)
+	}
+	g.tickCounter = g.frightenedUntilTick
+	g.endFrightened()
+	if red.State != entities.GhostScatter {
+		t.Fatalf("ghost should rejoin the schedule when frightened ends, got %v", red.State)
+	}
+}
+
+func TestRevivedGhostIsNotFrightenedAgain(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	g.frightenGhosts(frightenedDurationUpdates * 10)
+	red.X, red.Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+	if red.State != entities.GhostEaten {
+		t.Fatalf("frightened ghost should be eaten, got %v", red.State)
+	}
+	for i := 0; i < 600 && !red.State.IsRoaming(); i++ {
+		g.updateGhosts()
+	}
+	if !g.isFrightened() {
+		t.Fatal("test needs the frightened period to still be running")
+	}
+	if !red.State.IsRoaming() {
+		t.Fatalf("ghost should be back in the maze after respawning, got %v", red.State)
+	}
+	// Touching the player now costs a life instead of scoring
+	lives := g.lives
+	red.X, red.Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+	if g.lives != lives-1 {
+		t.Fatalf("revived ghost should be dangerous, lives %d -> %d", lives, g.lives)
+	}
+}
+
+func TestEyesDoNotCollide(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	red.State = entities.GhostEaten
+	red.X, red.Y = g.player.X, g.player.Y
+	lives, score := g.lives, g.score
+	g.checkPlayerGhostCollision()
+	if g.lives != lives || g.score != score {
+		t.Fatalf("eyes should pass through the player, lives %d score %d", g.lives, g.score)
+	}
+}
+
+func TestModeSwitchUpdatesRoamingGhosts(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	for i := 0; i < levelFor(1).modeSchedule[0]; i++ {
+		g.updateGhostMode()
+	}
+	if red.State != entities.GhostChase {
+		t.Fatalf("roaming ghost should follow the schedule into chase, got %v", red.State)
+	}
+	if pink := g.ghostOfKind(entities.GhostPink); pink.State != entities.GhostInHouse {
+		t.Fatalf("waiting ghost should not change state, got %v", pink.State)
+	}
+}
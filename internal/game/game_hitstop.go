@@ -0,0 +1,38 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+
+	"pacman/internal/entities"
+)
+
+// startHitStop freezes play for a moment after a ghost is eaten, showing the
+// points scored where the ghost was caught.
+func (g *Game) startHitStop(gh *entities.Ghost, points int) {
+	g.hitStopUntilTick = g.tickCounter + hitStopTicks
+	g.hitStopGhost = gh
+	g.addPopup(fmt.Sprint(points), gh.X, gh.Y, color.RGBA{R: 0, G: 255, B: 255, A: 255}, hitStopTicks)
+}
+
+func (g *Game) hitStopActive() bool {
+	return g.hitStopUntilTick > g.tickCounter
+}
+
+// updateHitStop runs one frozen tick. The player and ghosts hold still and
+// every play timer is pushed back so the freeze costs no time; only eyes
+// already on their way home keep moving.
+func (g *Game) updateHitStop() {
+	if g.frightenedUntilTick != 0 {
+		g.frightenedUntilTick++
+	}
+	if g.fruitActive() {
+		g.fruitUntilTick++
+	}
+	g.lastPelletTick++
+	for _, gh := range g.ghosts {
+		if gh != g.hitStopGhost && gh.State.IsEyes() {
+			g.updateGhost(gh)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func TestEatingGhostFreezesPlay(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	advance(g, readyTicks)
+	placePlayer(g, 6, 26, entities.DirLeft)
+	red := g.ghostOfKind(entities.GhostRed)
+	pink := g.ghostOfKind(entities.GhostPink)
+	g.frightenGhosts(levelFor(1).frightenedTicks)
+	red.X, red.Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+
+	if !g.hitStopActive() {
+		t.Fatal("eating a ghost should freeze play")
+	}
+	if len(g.popups) != 1 || g.popups[0].text != "200" || g.popups[0].x != red.X {
+		t.Fatalf("expected a 200 popup where the ghost was, got %+v", g.popups)
+	}
+	left := g.frightenedUntilTick - g.tickCounter
+	px, rx, ry, pinkY := g.player.X, red.X, red.Y, pink.Y
+	advance(g, hitStopTicks-1)
+	if g.player.X != px || red.X != rx || red.Y != ry || pink.Y != pinkY {
+		t.Fatal("player and ghosts should hold still during the freeze")
+	}
+	if got := g.frightenedUntilTick - g.tickCounter; got != left {
+		t.Fatalf("frightened timer should hold during the freeze, %d -> %d ticks left", left, got)
+	}
+	advance(g, 2)
+	if g.hitStopActive() || len(g.popups) != 0 {
+		t.Fatal("freeze and popup should be over")
+	}
+	if red.X == rx && red.Y == ry {
+		t.Fatal("eyes should head home after the freeze")
+	}
+}
+
+func TestEyesKeepMovingDuringFreeze(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	advance(g, readyTicks)
+	eyes := g.ghostOfKind(entities.GhostPink)
+	placeGhost(g, eyes, 6, 5, entities.DirLeft)
+	eyes.State = entities.GhostEaten
+	red := g.ghostOfKind(entities.GhostRed)
+	red.State = entities.GhostFrightened
+	red.X, red.Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+	ex, ey := eyes.X, eyes.Y
+	advance(g, 1)
+	if eyes.X == ex && eyes.Y == ey {
+		t.Fatal("eyes already heading home should not freeze")
+	}
+}
+
+func TestPauseHoldsHitStop(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	advance(g, readyTicks)
+	red := g.ghostOfKind(entities.GhostRed)
+	g.frightenGhosts(levelFor(1).frightenedTicks)
+	red.X, red.Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+	g.paused = true
+	advance(g, hitStopTicks*2)
+	g.paused = false
+	if !g.hitStopActive() || len(g.popups) != 1 {
+		t.Fatal("freeze and popup should outlast a pause")
+	}
+	advance(g, hitStopTicks)
+	if g.hitStopActive() || len(g.popups) != 0 {
+		t.Fatal("freeze and popup should end after their time in play")
+	}
+}
@@ -0,0 +1,186 @@
+package game
+
+import (
+	"math"
+
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+const (
+	// houseGlobalOrangeLimit is the global dot count at which the orange ghost
+	// is released after a life is lost; once reached with the orange ghost still
+	// inside, the personal counters take over again.
+	houseGlobalOrangeLimit = 32
+
+	houseBobRange = 4.0 // pixels above/below the spawn row while waiting
+	houseBobSpeed = 1.0 // pixels per update while waiting
+	houseDoorRate = 0.5 // fraction of ghost speed used to pass the door
+)
+
+// houseGlobalDotLimits replace the personal dot limits (indexed by
+// GhostKind) for the rest of a level once a life has been lost.
+var houseGlobalDotLimits = [4]int{0, 7, 17, houseGlobalOrangeLimit}
+
+// spawnGhosts creates the four ghosts in their spawn slots.
+func (g *Game) spawnGhosts() {
+	g.ghosts = g.ghosts[:0]
+	for i, kind := range ghostSpawnKinds {
+		gh := &entities.Ghost{Kind: kind}
+		g.spawnGhost(gh, i)
+		g.ghosts = append(g.ghosts, gh)
+	}
+}
+
+// house returns the ghost house of the current maze. The exit tile sits
+// right above the door; ghosts leave and eyes enter through the door column.
+func (g *Game) house() tm.House {
+	return g.tileMap.Layout.House
+}
+
+// spawnGhost puts the ghost in spawn slot i back at its starting tile from
+// the maze layout. A ghost starting on the house exit, or anywhere else
+// outside the house, is already out.
+func (g *Game) spawnGhost(gh *entities.Ghost, i int) {
+	t := g.tileMap.Layout.Ghosts[i]
+	gh.X, gh.Y = g.cellCenter(t.X, t.Y)
+	if t == g.house().Exit || !g.tileMap.InHouse(t) {
+		gh.State = g.roamingState(gh.Kind)
+		gh.CurrentDir = entities.DirLeft
+	} else {
+		gh.State = entities.GhostInHouse
+		gh.CurrentDir = entities.DirUp
+	}
+}
+
+// nextHouseGhost returns the ghost whose dot counter is active: the first
+// ghost, in personality order, still waiting in the house.
+func (g *Game) nextHouseGhost() *entities.Ghost {
+	for _, kind := range ghostSpawnKinds {
+		if gh := g.ghostOfKind(kind); gh != nil && gh.State == entities.GhostInHouse {
+			return gh
+		}
+	}
+	return nil
+}
+
+// countHouseDot credits an eaten pellet to the waiting ghost's dot counter,
+// or to the global counter after a life has been lost.
+func (g *Game) countHouseDot() {
+	g.lastPelletTick = g.tickCounter
+	if g.houseGlobalDots >= 0 {
+		g.houseGlobalDots++
+		return
+	}
+	if gh := g.nextHouseGhost(); gh != nil {
+		gh.DotCounter++
+	}
+}
+
+// updateGhostHouse releases the next waiting ghost once its dot counter
+// reaches the level's limit, or when the player stops eating for too long.
+func (g *Game) updateGhostHouse() {
+	gh := g.nextHouseGhost()
+	if g.houseGlobalDots >= 0 {
+		g.updateGlobalDots(gh)
+		return
+	}
+	if gh == nil {
+		return
+	}
+	spec := levelFor(g.level)
+	if gh.DotCounter >= spec.houseDotLimits[gh.Kind] {
+		gh.State = entities.GhostLeaving
+		return
+	}
+	if g.tickCounter-g.lastPelletTick >= spec.houseTimeout {
+		gh.State = entities.GhostLeaving
+		g.lastPelletTick = g.tickCounter
+	}
+}
+
+// updateGlobalDots releases ghosts on the global dot counter used after a
+// life is lost. The timeout still applies.
+func (g *Game) updateGlobalDots(gh *entities.Ghost) {
+	if gh == nil {
+		return
+	}
+	if gh.Kind == entities.GhostOrange && g.houseGlobalDots >= houseGlobalOrangeLimit {
+		// The orange ghost is still waiting: back to personal counters
+		g.houseGlobalDots = -1
+		return
+	}
+	if g.houseGlobalDots == houseGlobalDotLimits[gh.Kind] {
+		gh.State = entities.GhostLeaving
+		return
+	}
+	if g.tickCounter-g.lastPelletTick >= levelFor(g.level).houseTimeout {
+		gh.State = entities.GhostLeaving
+		g.lastPelletTick = g.tickCounter
+	}
+}
+
+// moveHouseGhost animates ghosts that are inside or passing through the door.
+func (g *Game) moveHouseGhost(gh *entities.Ghost) {
+	h := g.house()
+	doorX, exitY := g.cellCenter(h.Exit.X, h.Exit.Y)
+	_, centerY := g.cellCenter(h.Center.X, h.Center.Y)
+	speed := ghostSpeedPixelsPerUpdate * houseDoorRate
+
+	switch gh.State {
+	case entities.GhostInHouse:
+		// Bob up and down around the house row
+		if gh.Y <= centerY-houseBobRange {
+			gh.CurrentDir = entities.DirDown
+		} else if gh.Y >= centerY+houseBobRange {
+			gh.CurrentDir = entities.DirUp
+		}
+		if gh.CurrentDir == entities.DirDown {
+			gh.Y += houseBobSpeed
+		} else {
+			gh.Y -= houseBobSpeed
+		}
+	case entities.GhostLeaving:
+		if stepToward(gh, doorX, exitY, speed) {
+			// Ghosts join the current scatter/chase phase, so revived
+			// eyes never come back out frightened
+			gh.State = g.roamingState(gh.Kind)
+			gh.CurrentDir = entities.DirLeft
+		}
+	case entities.GhostEntering:
+		if stepToward(gh, doorX, centerY, speed) {
+			// Revived: head straight back out
+			gh.State = entities.GhostLeaving
+		}
+	}
+}
+
+// stepToward moves a ghost toward (tx, ty), lining up horizontally before
+// moving vertically, and reports whether it arrived.
+func stepToward(gh *entities.Ghost, tx, ty, speed float64) bool {
+	if dx := tx - gh.X; dx != 0 {
+		gh.X += math.Copysign(math.Min(speed, math.Abs(dx)), dx)
+		if dx > 0 {
+			gh.CurrentDir = entities.DirRight
+		} else {
+			gh.CurrentDir = entities.DirLeft
+		}
+		return false
+	}
+	if dy := ty - gh.Y; dy != 0 {
+		gh.Y += math.Copysign(math.Min(speed, math.Abs(dy)), dy)
+		if dy > 0 {
+			gh.CurrentDir = entities.DirDown
+		} else {
+			gh.CurrentDir = entities.DirUp
+		}
+		return false
+	}
+	return true
+}
+
+// isInsideHouse reports whether a ghost is moved by the house script rather
+// than by the maze rules.
+func isInsideHouse(gh *entities.Ghost) bool {
+	return gh.State == entities.GhostInHouse || gh.State == entities.GhostLeaving || gh.State == entities.GhostEntering
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func TestGhostsStartInHouse(t *testing.T) {
+	g := New()
+	for _, gh := range g.ghosts {
+		want := entities.GhostInHouse
+		if gh.Kind == entities.GhostRed {
+			want = entities.GhostScatter
+		}
+		if gh.State != want {
+			t.Fatalf("ghost %v: got state %v, want %v", gh.Kind, gh.State, want)
+		}
+	}
+}
+
+func TestDotCountersReleaseGhostsInOrder(t *testing.T) {
+	g := New()
+	pink := g.ghostOfKind(entities.GhostPink)
+	cyan := g.ghostOfKind(entities.GhostCyan)
+	orange := g.ghostOfKind(entities.GhostOrange)
+
+	g.updateGhostHouse()
+	if pink.State != entities.GhostLeaving {
+		t.Fatalf("pink has no dot limit on level 1 and should leave at once, got %v", pink.State)
+	}
+	limit := levelFor(1).houseDotLimits[entities.GhostCyan]
+	for i := 0; i < limit-1; i++ {
+		g.countHouseDot()
+		g.updateGhostHouse()
+	}
+	if cyan.State != entities.GhostInHouse {
+		t.Fatalf("cyan should wait for %d dots, got %v after %d", limit, cyan.State, cyan.DotCounter)
+	}
+	g.countHouseDot()
+	g.updateGhostHouse()
+	if cyan.State != entities.GhostLeaving {
+		t.Fatalf("cyan should leave after %d dots, got %v", limit, cyan.State)
+	}
+	if orange.DotCounter != 0 {
+		t.Fatalf("only the first waiting ghost should count dots, orange has %d", orange.DotCounter)
+	}
+}
+
+func TestHouseTimeoutReleasesGhost(t *testing.T) {
+	g := New()
+	g.updateGhostHouse() // pink leaves immediately
+	cyan := g.ghostOfKind(entities.GhostCyan)
+	g.tickCounter += levelFor(1).houseTimeout
+	g.updateGhostHouse()
+	if cyan.State != entities.GhostLeaving {
+		t.Fatalf("cyan should be released when the player stops eating, got %v", cyan.State)
+	}
+}
+
+func TestHouseTimeoutCountsFromPlay(t *testing.T) {
+	g := New()
+	cyan := g.ghostOfKind(entities.GhostCyan)
+	timeout := levelFor(1).houseTimeout
+	g.tileMap.EatPelletAt(g.playerGrid()) // the pellet under the spawn
+	advance(g, readyTicks+timeout-1)
+	g.paused = true
+	advance(g, timeout)
+	g.paused = false
+	if cyan.State != entities.GhostInHouse {
+		t.Fatalf("neither Ready nor a pause should count towards the house timeout, cyan is %v", cyan.State)
+	}
+	advance(g, 1)
+	if cyan.State != entities.GhostLeaving {
+		t.Fatalf("cyan should be released %d ticks into play, got %v", timeout, cyan.State)
+	}
+}
+
+func TestLeavingGhostExitsThroughDoor(t *testing.T) {
+	g := New()
+	gh := g.ghostOfKind(entities.GhostCyan)
+	gh.State = entities.GhostLeaving
+	for i := 0; i < 200 && gh.State == entities.GhostLeaving; i++ {
+		g.updateGhosts()
+	}
+	if !gh.State.IsRoaming() {
+		t.Fatalf("ghost never left the house, state %v at %.1f,%.1f", gh.State, gh.X, gh.Y)
+	}
+	if gx, gy := g.ghostGrid(gh); gy > g.house().Exit.Y || g.tileMap.IsWall(gx, gy) {
+		t.Fatalf("ghost should be outside the house after leaving, at tile %d,%d", gx, gy)
+	}
+}
+
+func TestEatenEyesReturnThroughDoor(t *testing.T) {
+	g := New()
+	gh := g.ghostOfKind(entities.GhostRed)
+	placeGhost(g, gh, 9, g.house().Exit.Y, entities.DirRight)
+	gh.State = entities.GhostEaten
+	sawEntering := false
+	for i := 0; i < 300 && !gh.State.IsRoaming(); i++ {
+		g.updateGhosts()
+		if gh.State == entities.GhostEntering {
+			sawEntering = true
+		}
+	}
+	if !sawEntering {
+		t.Fatal("eyes should enter the house through the door")
+	}
+	if !gh.State.IsRoaming() {
+		t.Fatalf("revived ghost should leave the house again, got %v", gh.State)
+	}
+}
+
+func TestEatenEyesFindTheirWayFromAcrossTheMaze(t *testing.T) {
+	g := New()
+	gh := g.ghostOfKind(entities.GhostRed)
+	placeGhost(g, gh, 1, 26, entities.DirLeft)
+	gh.State = entities.GhostEaten
+	for i := 0; i < 600 && gh.State == entities.GhostEaten; i++ {
+		g.updateGhosts()
+	}
+	if gh.State != entities.GhostEntering && gh.State != entities.GhostLeaving {
+		gx, gy := g.ghostGrid(gh)
+		t.Fatalf("eyes did not reach the house from the bottom corner, stuck at %d,%d", gx, gy)
+	}
+}
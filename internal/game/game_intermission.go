@@ -0,0 +1,138 @@
+package game
+
+import (
+	"image/color"
+
+	"pacman/internal/cutscene"
+	"pacman/internal/entities"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// intermissionFor returns the cutscene played after clearing the given
+// level, or nil when the next level follows straight away.
+func (g *Game) intermissionFor(level int) *cutscene.Timeline {
+	w := float64(g.tileMap.Width * tileSize)
+	y := float64(g.tileMap.Height*tileSize) / 2
+	switch level {
+	case 2:
+		return chaseAndReverse(w, y)
+	case 5:
+		return snaggedGhost(w, y)
+	case 9, 13, 17:
+		return patchedGhost(w, y)
+	default:
+		return nil
+	}
+}
+
+// chaseAndReverse: the red ghost chases Pac-Man off screen, then a giant
+// Pac-Man chases the frightened ghost back.
+func chaseAndReverse(w, y float64) *cutscene.Timeline {
+	return cutscene.New(460).
+		Sound(0, "intermission").
+		Place(0, "pacman", w+tileSize, y, "pacman").
+		Move(0, 180, "pacman", -2*tileSize, y).
+		Place(20, "ghost", w+tileSize, y, "ghost-red").
+		Move(20, 200, "ghost", -2*tileSize, y).
+		Place(240, "ghost", -2*tileSize, y, "ghost-frightened").
+		Move(240, 420, "ghost", w+2*tileSize, y).
+		Place(260, "pacman", -3*tileSize, y, "pacman-big").
+		Move(260, 440, "pacman", w+3*tileSize, y)
+}
+
+// snaggedGhost: the red ghost's sheet catches on a nail mid-chase and tears.
+func snaggedGhost(w, y float64) *cutscene.Timeline {
+	mid := w / 2
+	return cutscene.New(420).
+		Sound(0, "intermission").
+		Place(0, "nail", mid, y, "nail").
+		Place(0, "pacman", w+tileSize, y, "pacman").
+		Move(0, 180, "pacman", -2*tileSize, y).
+		Place(30, "ghost", w+tileSize, y, "ghost-red").
+		Move(30, 150, "ghost", mid+tileSize/2, y).
+		Move(150, 270, "ghost", mid+tileSize/4, y).
+		Sprite(270, "ghost", "ghost-torn").
+		Hide(270, "nail")
+}
+
+// patchedGhost: the patched-up red ghost chases Pac-Man, then comes back
+// without its sheet.
+func patchedGhost(w, y float64) *cutscene.Timeline {
+	return cutscene.New(460).
+		Sound(0, "intermission").
+		Place(0, "pacman", w+tileSize, y, "pacman").
+		Move(0, 180, "pacman", -2*tileSize, y).
+		Place(20, "ghost", w+tileSize, y, "ghost-patched").
+		Move(20, 200, "ghost", -2*tileSize, y).
+		Place(260, "ghost", -2*tileSize, y, "ghost-naked").
+		Move(260, 440, "ghost", w+2*tileSize, y)
+}
+
+// finishLevel moves on from a cleared board, through an intermission when
+// one follows this level.
+func (g *Game) finishLevel() {
+	if tl := g.intermissionFor(g.level); tl != nil {
+		g.cutscene = tl.Start()
+		g.setState(StateIntermission)
+		return
+	}
+	g.startLevel(g.level + 1)
+}
+
+// updateIntermission plays one tick of the cutscene.
+func (g *Game) updateIntermission() {
+	for _, sound := range g.cutscene.Step() {
+		if sound == "intermission" && g.audio != nil {
+			g.audio.PlayIntermission()
+		}
+	}
+	if g.cutscene.Done() {
+		g.cutscene = nil
+		g.startLevel(g.level + 1)
+	}
+}
+
+// skipIntermission jumps to the end of the running cutscene.
+func (g *Game) skipIntermission() {
+	if g.cutscene != nil {
+		g.cutscene.Skip()
+	}
+}
+
+func (g *Game) drawIntermission(dst *ebiten.Image) {
+	if g.cutscene == nil {
+		return
+	}
+	r := float32(tileSize/2 - 2)
+	for _, a := range g.cutscene.Actors() {
+		if !a.Visible {
+			continue
+		}
+		x, y := float32(a.X), float32(a.Y)
+		gh := &entities.Ghost{X: a.X, Y: a.Y, CurrentDir: a.Dir}
+		switch a.Sprite {
+		case "pacman":
+			vector.DrawFilledCircle(dst, x, y, r, color.RGBA{R: 255, G: 221, B: 0, A: 255}, true)
+		case "pacman-big":
+			vector.DrawFilledCircle(dst, x, y, 3*r, color.RGBA{R: 255, G: 221, B: 0, A: 255}, true)
+		case "ghost-red", "ghost-torn", "ghost-patched":
+			vector.DrawFilledCircle(dst, x, y, r, ghostColors[entities.GhostRed], true)
+			if a.Sprite == "ghost-torn" {
+				vector.StrokeLine(dst, x-r, y+r/2, x-r/2, y+r, 2, color.Black, true)
+			}
+			if a.Sprite == "ghost-patched" {
+				vector.DrawFilledRect(dst, x-2, y+2, 4, 4, color.RGBA{R: 255, G: 184, B: 151, A: 255}, false)
+			}
+			drawGhostEyes(dst, gh)
+		case "ghost-frightened":
+			vector.DrawFilledCircle(dst, x, y, r, color.RGBA{R: 0, G: 0, B: 255, A: 255}, true)
+		case "ghost-naked":
+			vector.DrawFilledCircle(dst, x, y, r*3/4, color.RGBA{R: 255, G: 184, B: 151, A: 255}, true)
+			drawGhostEyes(dst, gh)
+		case "nail":
+			vector.DrawFilledRect(dst, x-1, y, 2, r, color.RGBA{R: 255, G: 184, B: 151, A: 255}, false)
+		}
+	}
+}
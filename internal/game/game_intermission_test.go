@@ -0,0 +1,67 @@
+package game
+
+import "testing"
+
+// clearBoard finishes the current level and runs the flash.
+func clearBoard(g *Game) {
+	g.setState(StateLevelComplete)
+	advance(g, levelCompleteTicks)
+}
+
+func TestIntermissionAfterLevelTwo(t *testing.T) {
+	g := New()
+	clearBoard(g)
+	if g.State() != StateReady || g.level != 2 {
+		t.Fatalf("level 1 should lead straight to level 2, got %v on level %d", g.State(), g.level)
+	}
+	clearBoard(g)
+	if g.State() != StateIntermission {
+		t.Fatalf("expected an intermission after level 2, got %v", g.State())
+	}
+	for i := 0; i < 1000 && g.State() == StateIntermission; i++ {
+		advance(g, 1)
+	}
+	if g.State() != StateReady || g.level != 3 {
+		t.Fatalf("level 3 should follow the intermission, got %v on level %d", g.State(), g.level)
+	}
+}
+
+func TestSkipIntermission(t *testing.T) {
+	g := New()
+	g.level = 5
+	clearBoard(g)
+	if g.State() != StateIntermission {
+		t.Fatalf("expected an intermission after level 5, got %v", g.State())
+	}
+	advance(g, 10)
+	g.skipIntermission()
+	advance(g, 1)
+	if g.State() != StateReady || g.level != 6 {
+		t.Fatalf("skipping should start level 6, got %v on level %d", g.State(), g.level)
+	}
+}
+
+func TestIntermissionScenesEndOffStage(t *testing.T) {
+	g := New()
+	w := float64(g.tileMap.Width * tileSize)
+	for _, level := range []int{2, 9} {
+		p := g.intermissionFor(level).Start()
+		p.Skip()
+		pac, _ := p.Actor("pacman")
+		ghost, _ := p.Actor("ghost")
+		if level == 2 && pac.X <= w {
+			t.Fatalf("giant pacman should end off the right edge, got %.0f", pac.X)
+		}
+		if ghost.X <= w {
+			t.Fatalf("level %d: ghost should run off the right edge, got %.0f", level, ghost.X)
+		}
+	}
+	p := g.intermissionFor(5).Start()
+	p.Skip()
+	if ghost, _ := p.Actor("ghost"); ghost.Sprite != "ghost-torn" {
+		t.Fatalf("snagged ghost should end torn, got %q", ghost.Sprite)
+	}
+	if g.intermissionFor(3) != nil {
+		t.Fatal("no intermission expected after level 3")
+	}
+}
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	startingLives = 3
+	// maxLives caps extra life awards so the lives icons fit in the HUD.
+	maxLives            = 5
+	extraLifeFlashTicks = 2 * updatesPerSecond
+)
+
+// checkExtraLife awards a life for every extra life threshold the score has
+// passed. Awards beyond maxLives are dropped but still move the threshold on.
+func (g *Game) checkExtraLife() {
+	for g.nextExtraLife > 0 && g.score >= g.nextExtraLife {
+		if g.lives < maxLives {
+			g.lives++
+			g.extraLifeFlashUntil = g.tickCounter + extraLifeFlashTicks
+			if g.audio != nil {
+				g.audio.PlayExtraLife()
+			}
+		}
+		if g.opts.ExtraLifeEvery > 0 {
+			g.nextExtraLife += g.opts.ExtraLifeEvery
+		} else {
+			g.nextExtraLife = 0
+		}
+	}
+}
+
+// drawLivesIcons shows the lives in reserve along the bottom left, blinking
+// for a moment after an extra life is awarded.
+func (g *Game) drawLivesIcons(dst *ebiten.Image) {
+	if g.extraLifeFlashUntil > g.tickCounter && (g.tickCounter/8)%2 == 0 {
+		return
+	}
+	y := float32((g.tileMap.Height-2)*tileSize + tileSize/2)
+	for i := 0; i < g.lives-1; i++ {
+		x := float32(tileSize + i*tileSize)
+		vector.DrawFilledCircle(dst, x, y, float32(tileSize/2-3), color.RGBA{R: 255, G: 221, B: 0, A: 255}, true)
+	}
+}
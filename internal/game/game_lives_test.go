@@ -0,0 +1,71 @@
+package game
+
+import "testing"
+
+func TestExtraLifeOneTime(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(Options{ExtraLifeScore: 10000})
+	g.addScore(9990)
+	if g.lives != startingLives {
+		t.Fatalf("no extra life expected below the threshold, got %d lives", g.lives)
+	}
+	g.addScore(10)
+	if g.lives != startingLives+1 {
+		t.Fatalf("expected an extra life at 10000, got %d lives", g.lives)
+	}
+	if g.extraLifeFlashUntil <= g.tickCounter {
+		t.Fatal("HUD should flash after an extra life")
+	}
+	g.addScore(50000)
+	if g.lives != startingLives+1 {
+		t.Fatalf("one-time award should not repeat, got %d lives", g.lives)
+	}
+}
+
+func TestExtraLifeEveryN(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(Options{ExtraLifeScore: 10000, ExtraLifeEvery: 20000})
+	g.addScore(10000)
+	g.addScore(20000)
+	if g.lives != startingLives+2 {
+		t.Fatalf("expected lives at 10000 and 30000, got %d lives", g.lives)
+	}
+	if g.nextExtraLife != 50000 {
+		t.Fatalf("next award should be at 50000, got %d", g.nextExtraLife)
+	}
+}
+
+func TestExtraLivesAreCapped(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(Options{ExtraLifeScore: 1000, ExtraLifeEvery: 1000})
+	g.addScore(20000)
+	if g.lives != maxLives {
+		t.Fatalf("lives should be capped at %d, got %d", maxLives, g.lives)
+	}
+}
+
+func TestExtraLifeDisabled(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(Options{})
+	g.addScore(100000)
+	if g.lives != startingLives {
+		t.Fatalf("extra lives should be off, got %d lives", g.lives)
+	}
+}
+
+func TestExtraLifeOptionsFromEnv(t *testing.T) {
+	t.Setenv("PACMAN_EXTRA_LIFE", "")
+	t.Setenv("PACMAN_EXTRA_LIFE_EVERY", "")
+	if opts := OptionsFromEnv(); opts.ExtraLifeScore != 10000 || opts.ExtraLifeEvery != 0 {
+		t.Fatalf("default should be a single life at 10000, got %+v", opts)
+	}
+	t.Setenv("PACMAN_EXTRA_LIFE", "15000")
+	t.Setenv("PACMAN_EXTRA_LIFE_EVERY", "25000")
+	if opts := OptionsFromEnv(); opts.ExtraLifeScore != 15000 || opts.ExtraLifeEvery != 25000 {
+		t.Fatalf("expected 15000 then every 25000, got %+v", opts)
+	}
+	t.Setenv("PACMAN_EXTRA_LIFE", "lots")
+	if opts := OptionsFromEnv(); opts.ExtraLifeScore != 10000 {
+		t.Fatalf("invalid value should be ignored, got %d", opts.ExtraLifeScore)
+	}
+}
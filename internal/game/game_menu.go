@@ -0,0 +1,243 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// menuScreen is the page of the title screen front end being shown.
+type menuScreen int
+
+const (
+	menuMain        menuScreen = iota // Start, Leaderboard, Settings, Quit
+	menuName                          // name entry, the first step of Start
+	menuLeaderboard                   // top scores
+	menuSettings                      // difficulty and extra lives
+)
+
+// menuItem is an entry of the main menu.
+type menuItem int
+
+const (
+	menuStart menuItem = iota
+	menuShowLeaderboard
+	menuShowSettings
+	menuQuit
+)
+
+var menuItems = []string{"START", "LEADERBOARD", "SETTINGS", "QUIT"}
+
+// Rows of the settings page.
+const (
+	settingDifficulty = iota
+	settingExtraLife
+	settingCount
+)
+
+// extraLifeChoices are the first extra life thresholds offered in settings;
+// zero turns extra lives off.
+var extraLifeChoices = []int{0, 10000, 15000, 20000}
+
+var (
+	menuColor     = color.RGBA{R: 255, G: 221, B: 0, A: 255}
+	menuHintColor = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+)
+
+// handleMenuInput drives the title screen menu from the keyboard: arrows
+// move, Enter selects and Escape goes back.
+func (g *Game) handleMenuInput() {
+	if g.menu == menuName {
+		g.handleNameInput()
+		return
+	}
+	if len(inpututil.AppendJustPressedKeys(nil)) > 0 {
+		g.attractTicks = 0 // stay on the title while someone is at the keys
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		g.fullscreen = !g.fullscreen
+		ebiten.SetFullscreen(g.fullscreen)
+	}
+	back := inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyBackspace)
+	switch g.menu {
+	case menuMain:
+		g.menuIndex = wrapIndex(g.menuIndex+menuDelta(ebiten.KeyArrowUp, ebiten.KeyArrowDown), len(menuItems))
+		if menuSelect() {
+			g.selectMenuItem(menuItem(g.menuIndex))
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+			g.quit = true
+		}
+	case menuLeaderboard:
+		if back || menuSelect() {
+			g.menu = menuMain
+		}
+	case menuSettings:
+		g.settingsIndex = wrapIndex(g.settingsIndex+menuDelta(ebiten.KeyArrowUp, ebiten.KeyArrowDown), settingCount)
+		if d := menuDelta(ebiten.KeyArrowLeft, ebiten.KeyArrowRight); d != 0 {
+			g.changeSetting(g.settingsIndex, d)
+		}
+		if back || menuSelect() {
+			g.menu = menuMain
+		}
+	}
+}
+
+// handleNameInput collects the player's name. Enter starts the game once a
+// name is typed; Escape returns to the main menu.
+func (g *Game) handleNameInput() {
+	var chars []rune
+	chars = ebiten.AppendInputChars(chars)
+	for _, r := range chars {
+		if len([]rune(g.playerName)) >= maxPlayerNameLength {
+			break
+		}
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == ' ' || r == '_' || r == '-' {
+			g.playerName += string(r)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+		rs := []rune(g.playerName)
+		if len(rs) > 0 {
+			g.playerName = string(rs[:len(rs)-1])
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.enteringName = false
+		g.menu = menuMain
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyKPEnter) {
+		if len([]rune(g.playerName)) > 0 {
+			g.startGame()
+		}
+	}
+}
+
+// selectMenuItem acts on a main menu choice.
+func (g *Game) selectMenuItem(item menuItem) {
+	switch item {
+	case menuStart:
+		g.menu = menuName
+		g.enteringName = true
+	case menuShowLeaderboard:
+		g.menu = menuLeaderboard
+	case menuShowSettings:
+		g.menu = menuSettings
+	case menuQuit:
+		g.quit = true
+	}
+}
+
+// startGame leaves the front end for a fresh game under the entered name.
+func (g *Game) startGame() {
+	g.NewSession(g.playerName)
+	// Name-based easter eggs
+	low := strings.ToLower(strings.TrimSpace(g.playerName))
+	if low == "rekha" {
+		g.easterMessage = "Dad Loves Rekha"
+		g.easterUntilTick = g.tickCounter + updatesPerSecond*easterEggDuration
+	}
+	if low == "roy" {
+		g.easterMessage = "Dad Loves Roy"
+		g.easterUntilTick = g.tickCounter + updatesPerSecond*easterEggDuration
+	}
+}
+
+// changeSetting steps a settings row through its choices by delta.
+func (g *Game) changeSetting(row, delta int) {
+	switch row {
+	case settingDifficulty:
+		i := 0
+		for j, d := range difficulties {
+			if d == g.difficulty {
+				i = j
+			}
+		}
+		d := difficulties[wrapIndex(i+delta, len(difficulties))]
+		g.difficulty = d
+		g.opts.Difficulty = d
+	case settingExtraLife:
+		i := -1
+		for j, v := range extraLifeChoices {
+			if v == g.opts.ExtraLifeScore {
+				i = j
+			}
+		}
+		if i < 0 && delta < 0 {
+			i = 0 // unlisted value: step onto the list from either end
+		}
+		g.opts.ExtraLifeScore = extraLifeChoices[wrapIndex(i+delta, len(extraLifeChoices))]
+		g.nextExtraLife = g.opts.ExtraLifeScore
+	}
+}
+
+// settingLabel renders a settings row as shown on the settings page.
+func (g *Game) settingLabel(row int) string {
+	switch row {
+	case settingDifficulty:
+		return "DIFFICULTY  < " + strings.ToUpper(g.difficulty.String()) + " >"
+	case settingExtraLife:
+		if g.opts.ExtraLifeScore == 0 {
+			return "EXTRA LIFE  < OFF >"
+		}
+		return fmt.Sprintf("EXTRA LIFE  < %d >", g.opts.ExtraLifeScore)
+	}
+	return ""
+}
+
+// drawMenu draws the current page of the title screen menu.
+func (g *Game) drawMenu(dst *ebiten.Image) {
+	nativeH := g.tileMap.Height * tileSize
+	switch g.menu {
+	case menuMain:
+		for i, item := range menuItems {
+			c := color.Color(color.White)
+			if i == g.menuIndex {
+				item = "> " + item + " <"
+				c = menuColor
+			}
+			drawCentered(dst, item, (13+2*i)*tileSize, c)
+		}
+		drawCentered(dst, "Arrows: move  Enter: select", nativeH-4*tileSize, menuHintColor)
+		drawCentered(dst, "Q: quit  F: fullscreen", nativeH-2*tileSize, menuHintColor)
+	case menuName:
+		drawCentered(dst, "Enter name: "+g.playerName+"_", 14*tileSize, color.White)
+		drawCentered(dst, "Enter: play  Esc: back", nativeH-4*tileSize, menuHintColor)
+	case menuLeaderboard:
+		g.drawLeaderboard(dst, "Esc: back")
+	case menuSettings:
+		for row := 0; row < settingCount; row++ {
+			c := color.Color(color.White)
+			if row == g.settingsIndex {
+				c = menuColor
+			}
+			drawCentered(dst, g.settingLabel(row), (13+2*row)*tileSize, c)
+		}
+		drawCentered(dst, "Left/Right: change  Esc: back", nativeH-4*tileSize, menuHintColor)
+	}
+}
+
+// menuDelta turns a just-pressed pair of keys into a step of -1, 0 or +1.
+func menuDelta(prev, next ebiten.Key) int {
+	switch {
+	case inpututil.IsKeyJustPressed(prev):
+		return -1
+	case inpututil.IsKeyJustPressed(next):
+		return 1
+	}
+	return 0
+}
+
+// menuSelect reports whether a select key was just pressed.
+func menuSelect() bool {
+	return inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyKPEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace)
+}
+
+// wrapIndex keeps i within [0, n), wrapping around at either end.
+func wrapIndex(i, n int) int {
+	return ((i % n) + n) % n
+}
@@ -0,0 +1,81 @@
+package game
+
+import "testing"
+
+func TestNewGameOpensOnTheMenu(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	if g.attract != attractTitle || g.menu != menuMain || g.enteringName {
+		t.Fatalf("expected the main menu, got phase %d menu %d enteringName %v", g.attract, g.menu, g.enteringName)
+	}
+}
+
+func TestStartAsksForNameThenPlays(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.selectMenuItem(menuStart)
+	if g.menu != menuName || !g.enteringName {
+		t.Fatalf("Start should open name entry, got menu %d", g.menu)
+	}
+	// Name entry holds the title screen: no roll call while typing
+	runAttract(g, attractTitleTicks*2)
+	if g.attract != attractTitle {
+		t.Fatalf("name entry should not time out, got phase %d", g.attract)
+	}
+	g.playerName = "ann"
+	g.startGame()
+	if g.attract != attractOff || g.enteringName || g.menu != menuMain {
+		t.Fatalf("expected play to start, got phase %d menu %d", g.attract, g.menu)
+	}
+	if g.State() != StateReady || g.lives != startingLives || g.score != 0 {
+		t.Fatalf("expected a fresh game: state=%v lives=%d score=%d", g.State(), g.lives, g.score)
+	}
+}
+
+func TestMenuItemsOpenPages(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.selectMenuItem(menuShowLeaderboard)
+	if g.menu != menuLeaderboard {
+		t.Fatalf("expected the leaderboard page, got %d", g.menu)
+	}
+	g.menu = menuMain
+	g.selectMenuItem(menuShowSettings)
+	if g.menu != menuSettings {
+		t.Fatalf("expected the settings page, got %d", g.menu)
+	}
+	g.selectMenuItem(menuQuit)
+	if !g.quit {
+		t.Fatal("Quit should end the game")
+	}
+}
+
+func TestSettingsCycleThroughChoices(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.changeSetting(settingDifficulty, 1)
+	if g.difficulty != DifficultyHard || g.opts.Difficulty != DifficultyHard {
+		t.Fatalf("expected hard, got %v", g.difficulty)
+	}
+	g.changeSetting(settingDifficulty, 1)
+	if g.difficulty != DifficultyEasy {
+		t.Fatalf("expected difficulty to wrap to easy, got %v", g.difficulty)
+	}
+	g.changeSetting(settingExtraLife, -1)
+	if g.opts.ExtraLifeScore != 0 || g.nextExtraLife != 0 {
+		t.Fatalf("expected extra lives off, got %d", g.opts.ExtraLifeScore)
+	}
+	g.changeSetting(settingExtraLife, -1)
+	if g.opts.ExtraLifeScore != 20000 {
+		t.Fatalf("expected the threshold to wrap to 20000, got %d", g.opts.ExtraLifeScore)
+	}
+}
+
+func TestWrapIndex(t *testing.T) {
+	cases := []struct{ i, n, want int }{{0, 4, 0}, {4, 4, 0}, {-1, 4, 3}, {5, 4, 1}}
+	for _, c := range cases {
+		if got := wrapIndex(c.i, c.n); got != c.want {
+			t.Errorf("wrapIndex(%d, %d) = %d, want %d", c.i, c.n, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package game
+
+import "pacman/internal/entities"
+
+// GhostMode is the global behaviour the ghosts are following.
+type GhostMode int
+
+const (
+	ModeScatter GhostMode = iota
+	ModeChase
+	ModeFrightened
+)
+
+func (m GhostMode) String() string {
+	switch m {
+	case ModeScatter:
+		return "Scatter"
+	case ModeChase:
+		return "Chase"
+	case ModeFrightened:
+		return "Frightened"
+	default:
+		return "Unknown"
+	}
+}
+
+// GhostMode reports the mode ghosts are currently in. Frightened takes
+// precedence over the scatter/chase schedule, which is paused meanwhile.
+func (g *Game) GhostMode() GhostMode {
+	if g.isFrightened() {
+		return ModeFrightened
+	}
+	return g.scheduledMode()
+}
+
+// scheduledMode returns the scatter/chase mode for the current schedule phase.
+func (g *Game) scheduledMode() GhostMode {
+	if g.modePhase%2 == 0 {
+		return ModeScatter
+	}
+	return ModeChase
+}
+
+// updateGhostMode advances the scatter/chase schedule by one tick. The
+// schedule does not run while ghosts are frightened, and every switch makes
+// the ghosts reverse direction.
+func (g *Game) updateGhostMode() {
+	if g.isFrightened() {
+		return
+	}
+	schedule := levelFor(g.level).modeSchedule
+	if g.modePhase >= len(schedule)-1 {
+		return // final phase lasts forever
+	}
+	g.modeTicks++
+	if g.modeTicks >= schedule[g.modePhase] {
+		g.modePhase++
+		g.modeTicks = 0
+		for _, gh := range g.ghosts {
+			if gh.State.IsRoaming() {
+				gh.State = g.roamingState(gh.Kind)
+			}
+		}
+		g.reverseAllGhosts()
+	}
+}
+
+// roamingState is the state a ghost of the given kind takes in the maze
+// under the current scatter/chase phase. The red ghost keeps chasing through
+// scatter phases once Cruise Elroy kicks in.
+func (g *Game) roamingState(kind entities.GhostKind) entities.GhostState {
+	if g.scheduledMode() == ModeChase || (kind == entities.GhostRed && g.elroyStage() > 0) {
+		return entities.GhostChase
+	}
+	return entities.GhostScatter
+}
+
+// frightenGhosts starts frightened mode for every roaming ghost. Ghosts in
+// the house and eyes are unaffected.
+func (g *Game) frightenGhosts(duration int) {
+	g.frightenedUntilTick = g.tickCounter + duration
+	g.ghostEatCombo = 0
+	for _, gh := range g.ghosts {
+		if gh.State.IsRoaming() {
+			gh.State = entities.GhostFrightened
+		}
+	}
+	g.reverseAllGhosts()
+}
+
+// endFrightened returns any still-frightened ghosts to the schedule.
+func (g *Game) endFrightened() {
+	g.frightenedUntilTick = 0
+	g.ghostEatCombo = 0
+	for _, gh := range g.ghosts {
+		if gh.State == entities.GhostFrightened {
+			gh.State = g.roamingState(gh.Kind)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func TestGhostModeScheduleFollowsLevelTable(t *testing.T) {
+	g := New()
+	if g.GhostMode() != ModeScatter {
+		t.Fatalf("expected to start in scatter, got %v", g.GhostMode())
+	}
+	schedule := levelFor(1).modeSchedule
+	for i := 0; i < schedule[0]-1; i++ {
+		g.updateGhostMode()
+	}
+	if g.GhostMode() != ModeScatter {
+		t.Fatalf("expected scatter until tick %d, got %v", schedule[0], g.GhostMode())
+	}
+	g.ghosts[0].CurrentDir = entities.DirLeft
+	g.updateGhostMode()
+	if g.GhostMode() != ModeChase {
+		t.Fatalf("expected chase after %d ticks, got %v", schedule[0], g.GhostMode())
+	}
+	if g.ghosts[0].CurrentDir != entities.DirRight {
+		t.Fatalf("expected ghosts to reverse on mode switch, got %v", g.ghosts[0].CurrentDir)
+	}
+}
+
+func TestGhostModeSchedulePausedWhileFrightened(t *testing.T) {
+	g := New()
+	g.tickCounter = 100
+	g.frightenedUntilTick = 200
+	for i := 0; i < levelFor(1).modeSchedule[0]*2; i++ {
+		g.updateGhostMode()
+	}
+	if g.GhostMode() != ModeFrightened {
+		t.Fatalf("expected frightened mode, got %v", g.GhostMode())
+	}
+	if g.modePhase != 0 || g.modeTicks != 0 {
+		t.Fatalf("schedule should not advance while frightened, phase=%d ticks=%d", g.modePhase, g.modeTicks)
+	}
+	g.frightenedUntilTick = 0
+	g.updateGhostMode()
+	if g.GhostMode() != ModeScatter || g.modeTicks != 1 {
+		t.Fatalf("schedule should resume where it stopped, got %v ticks=%d", g.GhostMode(), g.modeTicks)
+	}
+}
+
+func TestScatterTargetsHomeCorner(t *testing.T) {
+	g := New()
+	for _, gh := range g.ghosts {
+		gh.State = g.roamingState(gh.Kind)
+		tx, ty := ClassicTarget(g.view(), *gh)
+		sx, sy := ScatterTarget(g.view(), gh.Kind)
+		if tx != sx || ty != sy {
+			t.Fatalf("ghost %v should target its corner %d,%d in scatter, got %d,%d", gh.Kind, sx, sy, tx, ty)
+		}
+	}
+}
+
+func TestGhostModeFinalPhaseLastsForever(t *testing.T) {
+	g := New()
+	schedule := levelFor(1).modeSchedule
+	g.modePhase = len(schedule) - 1
+	for i := 0; i < seconds(60); i++ {
+		g.updateGhostMode()
+	}
+	if g.modePhase != len(schedule)-1 || g.GhostMode() != ModeChase {
+		t.Fatalf("expected to stay in the final chase phase, got phase %d (%v)", g.modePhase, g.GhostMode())
+	}
+}
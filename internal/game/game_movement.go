@@ -2,12 +2,18 @@ package game
 
 import (
 	"math"
-	"math/rand"
 
 	"pacman/internal/entities"
+	"pacman/internal/pathfinding"
 )
 
+// playerSpeed returns how far the player moves per update on this level.
+func (g *Game) playerSpeed() float64 {
+	return playerSpeedPixelsPerUpdate * levelFor(g.level).playerSpeed
+}
+
 func (g *Game) updatePlayerMovement() {
+	speed := g.playerSpeed()
 	// Handle turning at intersections
 	if g.player.DesiredDir != g.player.CurrentDir {
 		// Check if we can turn
@@ -27,8 +33,8 @@ func (g *Game) updatePlayerMovement() {
 	// Move in current direction
 	if g.player.CurrentDir != entities.DirNone {
 		dx, dy := entities.DirDelta(g.player.CurrentDir)
-		newX := g.player.X + float64(dx)*playerSpeedPixelsPerUpdate
-		newY := g.player.Y + float64(dy)*playerSpeedPixelsPerUpdate
+		newX := g.player.X + float64(dx)*speed
+		newY := g.player.Y + float64(dy)*speed
 
 		// Auto-center on the perpendicular axis when close to center to prevent drift
 		gx, gy := g.playerGrid()
@@ -127,7 +133,7 @@ func (g *Game) canTurn(dir entities.Direction) bool {
 		// If currently moving horizontally, detect if we'll cross the center next update
 		cdx, _ := entities.DirDelta(g.player.CurrentDir)
 		if cdx != 0 {
-			nextX := g.player.X + float64(cdx)*playerSpeedPixelsPerUpdate
+			nextX := g.player.X + float64(cdx)*g.playerSpeed()
 			// If the sign changes or we land exactly on center, allow the turn
 			if (g.player.X-cx)*(nextX-cx) <= 0 {
 				return true
@@ -143,7 +149,7 @@ func (g *Game) canTurn(dir entities.Direction) bool {
 		}
 		_, cdy := entities.DirDelta(g.player.CurrentDir)
 		if cdy != 0 {
-			nextY := g.player.Y + float64(cdy)*playerSpeedPixelsPerUpdate
+			nextY := g.player.Y + float64(cdy)*g.playerSpeed()
 			if (g.player.Y-cy)*(nextY-cy) <= 0 {
 				return true
 			}
@@ -213,282 +219,119 @@ func (g *Game) isValidPosition(x, y float64) bool {
 	return true
 }
 
-// Ghost behavior: random movement or fleeing behavior based on frightened state
-func (g *Game) updateGhostsRandom() {
+// updateGhosts advances every ghost along the maze. Ghosts pick a new
+// direction each time they reach the center of a tile.
+func (g *Game) updateGhosts() {
 	for _, gh := range g.ghosts {
-		// If not aligned to tile center, continue current direction
-		gx := int(gh.X) / tileSize
-		gy := int(gh.Y) / tileSize
-		cx := float64(gx*tileSize + tileSize/2)
-		cy := float64(gy*tileSize + tileSize/2)
-		aligned := math.Abs(gh.X-cx) < 1.0 && math.Abs(gh.Y-cy) < 1.0
-
-		if aligned {
-			// Choose direction based on ghost state and global frightened state
-			var chosenDir entities.Direction
-			if gh.State == entities.GhostEaten {
-				chosenDir = g.getDirectionTowardTarget(gx, gy, 14, 14)
-			} else if g.isFrightened() {
-				chosenDir = g.getFleeDirection(gh, gx, gy)
-			} else {
-				chosenDir = g.getRandomDirection(gh, gx, gy)
-			}
-			gh.CurrentDir = chosenDir
-			// Snap to center when aligned to avoid drift
-			gh.X = cx
-			gh.Y = cy
-		}
-
-		// Move ghost with appropriate speed, but only if not blocked
-		speed := ghostSpeedPixelsPerUpdate
-		if gh.State == entities.GhostEaten {
-			speed *= 1.5 // eyes return faster
-		} else if g.isFrightened() {
-			speed *= 0.5 // 50% speed when frightened
-		}
-
-		// Use proper collision detection like player movement
-		if g.canMoveGhost(gh, gh.CurrentDir) {
-			dx, dy := entities.DirDelta(gh.CurrentDir)
-			gh.X += float64(dx) * speed
-			gh.Y += float64(dy) * speed
-		} else {
-			// If blocked, snap to center and force new direction choice
-			gh.X = cx
-			gh.Y = cy
-			// Force alignment so ghost will choose new direction next frame
-			aligned = true
-			// Try to choose a new valid direction immediately
-			var chosenDir entities.Direction
-			if g.isFrightened() {
-				chosenDir = g.getFleeDirection(gh, gx, gy)
-			} else {
-				chosenDir = g.getRandomDirection(gh, gx, gy)
-			}
-			gh.CurrentDir = chosenDir
-		}
-
-		// If eaten and reached house center, restore to normal state
-		if gh.State == entities.GhostEaten {
-			houseX := float64(14*tileSize + tileSize/2)
-			houseY := float64(14*tileSize + tileSize/2)
-			if math.Abs(gh.X-houseX) < 1.0 && math.Abs(gh.Y-houseY) < 1.0 {
-				gh.State = entities.GhostNormal
-				gh.CurrentDir = entities.DirLeft
-				gh.X = houseX
-				gh.Y = houseY
-			}
-		}
-
-		// wrap horizontally
-		maxX := float64(g.tileMap.Width * tileSize)
-		if gh.X < 0 {
-			gh.X += maxX
-		}
-		if gh.X >= maxX {
-			gh.X -= maxX
-		}
-		// clamp Y within bounds to avoid exiting map vertically
-		minY := float64(tileSize / 2)
-		maxY := float64(g.tileMap.Height*tileSize - tileSize/2)
-		if gh.Y < minY {
-			gh.Y = minY
-		}
-		if gh.Y > maxY {
-			gh.Y = maxY
-		}
+		g.updateGhost(gh)
 	}
 }
 
-// getFleeDirection chooses the direction that maximizes distance from player
-func (g *Game) getFleeDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
-	playerX, playerY := g.playerGrid()
-	candidateDirs := []entities.Direction{entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight}
-	valid := make([]entities.Direction, 0, 4)
-
-	// Find all valid directions
-	for _, d := range candidateDirs {
-		dx, dy := entities.DirDelta(d)
-		nx, ny := gx+dx, gy+dy
-		// Handle wrap-around
-		if nx < 0 {
-			nx = g.tileMap.Width - 1
-		}
-		if nx >= g.tileMap.Width {
-			nx = 0
-		}
-		if ny < 0 || ny >= g.tileMap.Height {
-			continue
-		}
-		if !g.tileMap.IsWall(nx, ny) {
-			valid = append(valid, d)
-		}
+// updateGhost advances a single ghost, through the house script or the maze.
+func (g *Game) updateGhost(gh *entities.Ghost) {
+	if isInsideHouse(gh) {
+		g.moveHouseGhost(gh)
+		return
 	}
+	g.moveGhost(gh, g.ghostSpeed(gh))
 
-	if len(valid) == 0 {
-		// Emergency fallback
-		return g.getRandomDirection(gh, gx, gy)
+	// clamp Y within bounds to avoid exiting map vertically
+	minY := float64(tileSize / 2)
+	maxY := float64(g.tileMap.Height*tileSize - tileSize/2)
+	if gh.Y < minY {
+		gh.Y = minY
 	}
-
-	// Find direction that maximizes distance from player
-	bestDir := valid[0]
-	maxDistSq := float64(-1)
-
-	for _, d := range valid {
-		dx, dy := entities.DirDelta(d)
-		nx, ny := gx+dx, gy+dy
-		// Handle wrap-around for distance calculation
-		if nx < 0 {
-			nx = g.tileMap.Width - 1
-		}
-		if nx >= g.tileMap.Width {
-			nx = 0
-		}
-
-		// Calculate distance squared to player
-		distSq := float64((nx-playerX)*(nx-playerX) + (ny-playerY)*(ny-playerY))
-		if distSq > maxDistSq {
-			maxDistSq = distSq
-			bestDir = d
-		}
+	if gh.Y > maxY {
+		gh.Y = maxY
 	}
-
-	return bestDir
 }
 
-// getRandomDirection chooses a random valid direction (original behavior)
-func (g *Game) getRandomDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
-	candidateDirs := []entities.Direction{entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight}
-	// place current direction first to bias straight
-	ordered := make([]entities.Direction, 0, 4)
-	if gh.CurrentDir != entities.DirNone {
-		ordered = append(ordered, gh.CurrentDir)
+// ghostSpeed returns how far a ghost in the maze moves this update. Tunnels
+// slow down everything but eyes, and never speed a ghost up.
+func (g *Game) ghostSpeed(gh *entities.Ghost) float64 {
+	speed := ghostSpeedPixelsPerUpdate * levelFor(g.level).ghostSpeed
+	if gh.State.IsEyes() {
+		return speed * 1.5 // eyes return faster
 	}
-	for _, d := range candidateDirs {
-		if d != gh.CurrentDir && !isReverse(gh.CurrentDir, d) {
-			ordered = append(ordered, d)
-		}
+	rate := g.elroySpeed(gh)
+	if gh.State == entities.GhostFrightened {
+		rate = 0.5 // 50% speed when frightened
 	}
-	// If still empty (at start), just use candidates shuffled
-	if len(ordered) == 0 {
-		ordered = candidateDirs
+	if gx, gy := g.ghostGrid(gh); g.tileMap.IsTunnel(gx, gy) {
+		rate = math.Min(rate, levelFor(g.level).tunnelSpeed)
 	}
-	rand.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	return speed * rate
+}
 
-	for _, d := range ordered {
-		dx, dy := entities.DirDelta(d)
-		nx, ny := gx+dx, gy+dy
-		if nx < 0 {
-			nx = g.tileMap.Width - 1
-		}
-		if nx >= g.tileMap.Width {
-			nx = 0
-		}
-		if ny < 0 || ny >= g.tileMap.Height {
-			continue
+// moveGhost moves a ghost up to dist pixels. Movement stops on every tile
+// center it passes so that the ghost can choose a new direction there, then
+// carries on with the remaining distance.
+func (g *Game) moveGhost(gh *entities.Ghost, dist float64) {
+	maxX := float64(g.tileMap.Width * tileSize)
+	for dist > ghostCenterEpsilon {
+		gx, gy := g.ghostGrid(gh)
+		cx, cy := g.cellCenter(gx, gy)
+		atCenter := math.Abs(gh.X-cx) < ghostCenterEpsilon && math.Abs(gh.Y-cy) < ghostCenterEpsilon
+		if atCenter {
+			gh.X, gh.Y = cx, cy
+			g.ghostReachedCenter(gh, gx, gy)
+			if isInsideHouse(gh) {
+				return
+			}
+			gh.CurrentDir = g.chooseGhostDirection(gh, gx, gy)
 		}
-		if !g.tileMap.IsWall(nx, ny) {
-			return d
+		if gh.CurrentDir == entities.DirNone {
+			return
 		}
-	}
 
-	// If no valid moves found, find any valid direction
-	valid := make([]entities.Direction, 0, 4)
-	for _, d := range candidateDirs {
-		dx, dy := entities.DirDelta(d)
-		nx, ny := gx+dx, gy+dy
-		if nx < 0 {
-			nx = g.tileMap.Width - 1
-		}
-		if nx >= g.tileMap.Width {
-			nx = 0
-		}
-		if ny < 0 || ny >= g.tileMap.Height {
-			continue
+		// Distance to the next tile center along the current direction
+		dx, dy := entities.DirDelta(gh.CurrentDir)
+		toNext := (cx-gh.X)*float64(dx) + (cy-gh.Y)*float64(dy)
+		if toNext <= ghostCenterEpsilon {
+			toNext += tileSize
 		}
-		if !g.tileMap.IsWall(nx, ny) {
-			valid = append(valid, d)
-		}
-	}
+		step := math.Min(dist, toNext)
+		gh.X += float64(dx) * step
+		gh.Y += float64(dy) * step
+		dist -= step
 
-	if len(valid) == 0 {
-		// Emergency fallback: try reverse direction first
-		reverse := reverseDir(gh.CurrentDir)
-		dx, dy := entities.DirDelta(reverse)
-		nx, ny := gx+dx, gy+dy
-		if nx < 0 {
-			nx = g.tileMap.Width - 1
-		}
-		if nx >= g.tileMap.Width {
-			nx = 0
+		// wrap horizontally
+		if gh.X < 0 {
+			gh.X += maxX
 		}
-		if ny >= 0 && ny < g.tileMap.Height && !g.tileMap.IsWall(nx, ny) {
-			return reverse
+		if gh.X >= maxX {
+			gh.X -= maxX
 		}
-		// Final fallback
-		return entities.DirLeft
 	}
-
-	return valid[0]
 }
 
-// getDirectionTowardTarget returns a valid direction that moves toward the target grid cell.
-// It prioritizes reducing the larger of the horizontal/vertical distance first, and avoids walls.
-func (g *Game) getDirectionTowardTarget(gx, gy, tx, ty int) entities.Direction {
-	// simple greedy: try axis with greater distance first
-	dx := tx - gx
-	dy := ty - gy
-	try := make([]entities.Direction, 0, 4)
-	if abs(dx) >= abs(dy) {
-		if dx > 0 {
-			try = append(try, entities.DirRight)
-		} else if dx < 0 {
-			try = append(try, entities.DirLeft)
-		}
-		if dy > 0 {
-			try = append(try, entities.DirDown)
-		} else if dy < 0 {
-			try = append(try, entities.DirUp)
-		}
-	} else {
-		if dy > 0 {
-			try = append(try, entities.DirDown)
-		} else if dy < 0 {
-			try = append(try, entities.DirUp)
-		}
-		if dx > 0 {
-			try = append(try, entities.DirRight)
-		} else if dx < 0 {
-			try = append(try, entities.DirLeft)
-		}
+// ghostReachedCenter handles arrival-based state changes, such as eyes
+// reaching the ghost house door.
+func (g *Game) ghostReachedCenter(gh *entities.Ghost, gx, gy int) {
+	if exit := g.house().Exit; gh.State == entities.GhostEaten && gx == exit.X && gy == exit.Y {
+		gh.State = entities.GhostEntering
 	}
-	// add fallbacks
-	try = append(try, entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight)
-
-	for _, dir := range try {
-		ddx, ddy := entities.DirDelta(dir)
-		nx, ny := gx+ddx, gy+ddy
-		if nx < 0 {
-			nx = g.tileMap.Width - 1
-		}
-		if nx >= g.tileMap.Width {
-			nx = 0
-		}
-		if ny < 0 || ny >= g.tileMap.Height {
-			continue
-		}
-		if !g.tileMap.IsWall(nx, ny) {
-			return dir
-		}
+}
+
+// directionHome follows the shortest path to the ghost house exit.
+func (g *Game) directionHome(gh *entities.Ghost, gx, gy int) entities.Direction {
+	exit := g.house().Exit
+	if d := g.paths.Field(pathfinding.Point{X: exit.X, Y: exit.Y}).NextDirection(gx, gy); d != entities.DirNone {
+		return d
 	}
-	return entities.DirLeft
+	return g.directionToTarget(gh, gx, gy, exit.X, exit.Y)
 }
 
-func abs(a int) int {
-	if a < 0 {
-		return -a
+// chooseGhostDirection decides where a ghost standing on a tile center goes next.
+func (g *Game) chooseGhostDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
+	switch {
+	case gh.State == entities.GhostEaten:
+		return g.directionHome(gh, gx, gy)
+	case gh.State == entities.GhostFrightened:
+		return g.frightenedDirection(gh, gx, gy)
+	default:
+		return g.brainDirection(gh, gx, gy)
 	}
-	return a
 }
 
 func (g *Game) playerGrid() (int, int) {
@@ -512,30 +355,6 @@ func (g *Game) isNearCellCenter() bool {
 	return math.Abs(g.player.X-cx) < 5.0 && math.Abs(g.player.Y-cy) < 5.0
 }
 
-// canMoveGhost checks if a ghost can move in a direction from its current position
-func (g *Game) canMoveGhost(gh *entities.Ghost, dir entities.Direction) bool {
-	if dir == entities.DirNone {
-		return false
-	}
-	dx, dy := entities.DirDelta(dir)
-	gx := int(gh.X) / tileSize
-	gy := int(gh.Y) / tileSize
-
-	// Next cell
-	nx, ny := gx+dx, gy+dy
-	// Wrap-around checks on X
-	if nx < 0 {
-		nx = g.tileMap.Width - 1
-	}
-	if nx >= g.tileMap.Width {
-		nx = 0
-	}
-	if ny < 0 || ny >= g.tileMap.Height {
-		return false
-	}
-	return !g.tileMap.IsWall(nx, ny)
-}
-
 func isReverse(a, b entities.Direction) bool {
 	return (a == entities.DirUp && b == entities.DirDown) ||
 		(a == entities.DirDown && b == entities.DirUp) ||
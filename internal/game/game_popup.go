@@ -0,0 +1,41 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+)
+
+// popup is a short-lived message drawn over the maze, such as the points
+// scored for a fruit.
+type popup struct {
+	text      string
+	x, y      float64 // center, in native pixels
+	c         color.Color
+	untilTick int
+}
+
+// addPopup shows msg centered on (x, y) for the given number of ticks.
+func (g *Game) addPopup(msg string, x, y float64, c color.Color, ticks int) {
+	g.popups = append(g.popups, popup{text: msg, x: x, y: y, c: c, untilTick: g.tickCounter + ticks})
+}
+
+// updatePopups drops popups whose time is up.
+func (g *Game) updatePopups() {
+	kept := g.popups[:0]
+	for _, p := range g.popups {
+		if g.tickCounter < p.untilTick {
+			kept = append(kept, p)
+		}
+	}
+	g.popups = kept
+}
+
+func (g *Game) drawPopups(dst *ebiten.Image) {
+	for _, p := range g.popups {
+		w := len(p.text) * fontCharWidth
+		text.Draw(dst, p.text, basicfont.Face7x13, int(p.x)-w/2, int(p.y)+4, p.c)
+	}
+}
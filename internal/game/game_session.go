@@ -0,0 +1,128 @@
+package game
+
+import (
+	"image/color"
+
+	"pacman/internal/pathfinding"
+	tm "pacman/internal/tilemap"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// gameOverItem is an entry of the menu shown with the leaderboard after a
+// game ends.
+type gameOverItem int
+
+const (
+	gameOverPlayAgain gameOverItem = iota
+	gameOverChangePlayer
+	gameOverQuit
+)
+
+var gameOverItems = []string{"PLAY AGAIN", "CHANGE PLAYER", "QUIT"}
+
+// Reset throws away the current game and sets up a fresh one at level one:
+// score, lives, a new copy of the maze, new ghosts and all timers. The
+// window, audio and high score table are kept.
+func (g *Game) Reset() {
+	g.useMaze(g.opts.mazeFor(1))
+	g.spawnGhosts()
+	g.score = 0
+	g.lives = startingLives
+	g.nextExtraLife = g.opts.ExtraLifeScore
+	g.extraLifeFlashUntil = 0
+	g.fruitsCollected = nil
+	g.cutscene = nil
+	g.paused = false
+	g.showingLeaderboard = false
+	g.gameOverIndex = 0
+	g.easterMessage = ""
+	g.easterUntilTick = 0
+	g.startLevel(1)
+}
+
+// NewSession resets the game and starts playing it as the named player,
+// skipping the title screen.
+func (g *Game) NewSession(playerName string) {
+	g.Reset()
+	g.playerName = playerName
+	g.enteringName = false
+	g.menu = menuMain
+	g.setAttract(attractOff)
+	g.lastPelletTick = g.tickCounter
+}
+
+// newTileMap returns a fresh copy of a configured maze, or of the built-in
+// maze for nil.
+func newTileMap(src *tm.TileMap) *tm.TileMap {
+	if src != nil {
+		return src.Clone()
+	}
+	return tm.NewDefaultMap(tileSize)
+}
+
+// useMaze starts playing on a fresh copy of a configured maze.
+func (g *Game) useMaze(src *tm.TileMap) {
+	g.mazeSource = src
+	g.setTileMap(newTileMap(src))
+}
+
+// setTileMap switches to a maze and rebuilds the distance fields for it.
+func (g *Game) setTileMap(m *tm.TileMap) {
+	g.tileMap = m
+	g.paths = pathfinding.NewCache(m)
+	// Precompute the route home for eaten ghosts
+	exit := m.Layout.House.Exit
+	g.paths.Field(pathfinding.Point{X: exit.X, Y: exit.Y})
+}
+
+// gameOverMenuShowing reports whether the leaderboard is up after a game
+// has ended, with the choice of what to do next.
+func (g *Game) gameOverMenuShowing() bool {
+	return g.state == StateGameOver && g.showingLeaderboard
+}
+
+// handleGameOverInput drives the game over menu. Q still quits directly.
+func (g *Game) handleGameOverInput() {
+	g.gameOverIndex = wrapIndex(g.gameOverIndex+menuDelta(ebiten.KeyArrowUp, ebiten.KeyArrowDown), len(gameOverItems))
+	if menuSelect() {
+		g.selectGameOverItem(gameOverItem(g.gameOverIndex))
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		g.fullscreen = !g.fullscreen
+		ebiten.SetFullscreen(g.fullscreen)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
+		g.quit = true
+	}
+}
+
+// selectGameOverItem acts on a game over menu choice.
+func (g *Game) selectGameOverItem(item gameOverItem) {
+	switch item {
+	case gameOverPlayAgain:
+		g.NewSession(g.playerName)
+	case gameOverChangePlayer:
+		g.Reset()
+		g.playerName = ""
+		g.setAttract(attractTitle)
+		g.selectMenuItem(menuStart)
+	case gameOverQuit:
+		g.quit = true
+	}
+}
+
+// drawGameOverMenu draws the leaderboard with the game over choices below.
+func (g *Game) drawGameOverMenu(dst *ebiten.Image) {
+	g.drawLeaderboard(dst, "Arrows: move  Enter: select  Q: quit")
+	for i, item := range gameOverItems {
+		c := color.Color(color.White)
+		if i == g.gameOverIndex {
+			item = "> " + item + " <"
+			c = menuColor
+		}
+		drawCentered(dst, item, (23+i)*tileSize, c)
+	}
+}
@@ -0,0 +1,165 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+// endGame plays out the last life and waits for the game over leaderboard.
+func endGame(g *Game) {
+	g.lives = 1
+	catchPlayer(g)
+	advance(g, lifeLostFreezeTicks+deathTicks+gameOverTicks)
+}
+
+func TestGameOverShowsMenu(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.NewSession("ann")
+	advance(g, readyTicks)
+	endGame(g)
+	if !g.gameOverMenuShowing() {
+		t.Fatalf("expected the game over menu, state=%v leaderboard=%v", g.State(), g.showingLeaderboard)
+	}
+}
+
+func TestPlayAgainStartsFreshGame(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.NewSession("ann")
+	advance(g, readyTicks)
+	oldMap := g.tileMap
+	eatPellets(g, 20)
+	g.addScore(200)
+	g.level = 3
+	g.fruitsCollected = append(g.fruitsCollected, fruitFor(1).kind)
+	endGame(g)
+
+	g.selectGameOverItem(gameOverPlayAgain)
+	if g.gameOverMenuShowing() || g.State() != StateReady || g.attract != attractOff {
+		t.Fatalf("expected a new game to start, state=%v attract=%d", g.State(), g.attract)
+	}
+	if g.score != 0 || g.lives != startingLives || g.level != 1 || len(g.fruitsCollected) != 0 {
+		t.Fatalf("session not reset: score=%d lives=%d level=%d fruit=%d", g.score, g.lives, g.level, len(g.fruitsCollected))
+	}
+	if g.tileMap == oldMap {
+		t.Fatal("expected a fresh copy of the maze")
+	}
+	if got, want := g.tileMap.PelletsRemaining(), tm.NewDefaultMap(tileSize).PelletsRemaining(); got != want {
+		t.Fatalf("expected a full board, got %d pellets want %d", got, want)
+	}
+	if g.playerName != "ann" {
+		t.Fatalf("Play again should keep the player, got %q", g.playerName)
+	}
+}
+
+func TestChangePlayerReturnsToNameEntry(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.NewSession("ann")
+	advance(g, readyTicks)
+	endGame(g)
+
+	g.selectGameOverItem(gameOverChangePlayer)
+	if g.attract != attractTitle || g.menu != menuName || !g.enteringName || g.playerName != "" {
+		t.Fatalf("expected name entry for a new player, got attract=%d menu=%d name=%q", g.attract, g.menu, g.playerName)
+	}
+	if g.showingLeaderboard || g.lives != startingLives {
+		t.Fatalf("expected a reset session, leaderboard=%v lives=%d", g.showingLeaderboard, g.lives)
+	}
+}
+
+func TestResetKeepsHighScore(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := NewWithOptions(DefaultOptions())
+	g.NewSession("ann")
+	g.addScore(1234)
+	g.Reset()
+	if g.score != 0 || g.highScore != 1234 {
+		t.Fatalf("expected score reset and high score kept, got score=%d high=%d", g.score, g.highScore)
+	}
+	if len(g.ghosts) != len(ghostSpawnKinds) {
+		t.Fatalf("expected %d ghosts, got %d", len(ghostSpawnKinds), len(g.ghosts))
+	}
+}
+
+func TestConfiguredMazeIsCopiedPerGame(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	maze, err := LoadMaze("../../assets/mazes/classic.txt")
+	if err != nil {
+		t.Fatalf("LoadMaze: %v", err)
+	}
+	g := NewWithOptions(Options{Maze: maze})
+	if g.tileMap == maze {
+		t.Fatal("the game should play on its own copy of the maze")
+	}
+	g.tileMap.EatPelletAt(1, 1)
+	g.Reset()
+	if g.tileMap.Tiles[1][1] != tm.TilePellet || maze.Tiles[1][1] != tm.TilePellet {
+		t.Fatal("Reset should start from a full copy of the configured maze")
+	}
+}
+
+func TestGameStartsFromMazeLayout(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	src := strings.Join([]string{
+		"###########",
+		"#....1....#",
+		"#.###-###.#",
+		"#.#2 3 4#.#",
+		"#.#######.#",
+		"#.P..F....#",
+		"###########",
+	}, "\n")
+	maze, err := tm.LoadFromReader(strings.NewReader(src), tileSize)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	g := NewWithOptions(Options{Maze: maze})
+	if x, y := g.playerGrid(); x != 2 || y != 5 {
+		t.Errorf("player starts at %d,%d, want 2,5", x, y)
+	}
+	want := [][2]int{{5, 1}, {3, 3}, {5, 3}, {7, 3}}
+	for i, gh := range g.ghosts {
+		if x, y := g.ghostGrid(gh); x != want[i][0] || y != want[i][1] {
+			t.Errorf("ghost %d starts at %d,%d, want %v", i, x, y, want[i])
+		}
+	}
+	if g.ghosts[0].State == entities.GhostInHouse || g.ghosts[1].State != entities.GhostInHouse {
+		t.Error("red should start outside the house and the others inside")
+	}
+	if x, y := g.fruitPosition(); x != float64(5*tileSize+tileSize/2) || y != float64(5*tileSize+tileSize/2) {
+		t.Errorf("fruit at %v,%v, want the F tile", x, y)
+	}
+}
+
+func TestGhostSpawnOutsideHouseRoams(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	src := strings.Join([]string{
+		"###########",
+		"#.........#",
+		"#.###-###.#",
+		"#.#2 3  #.#",
+		"#.#######.#",
+		"#.P..F..4.#",
+		"###########",
+	}, "\n")
+	maze, err := tm.LoadFromReader(strings.NewReader(src), tileSize)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	g := NewWithOptions(Options{Maze: maze})
+	orange := g.ghostOfKind(entities.GhostOrange)
+	if !orange.State.IsRoaming() {
+		t.Fatalf("a ghost starting outside the house should roam, got %v", orange.State)
+	}
+	for i := 0; i < 200; i++ {
+		g.updateGhosts()
+		if x, y := g.ghostGrid(orange); g.tileMap.IsWall(x, y) {
+			t.Fatalf("ghost went into a wall at %d,%d", x, y)
+		}
+	}
+}
@@ -1,38 +1,172 @@
 package game
 
-import (
-	"pacman/internal/entities"
-	tm "pacman/internal/tilemap"
+import "pacman/internal/entities"
+
+// GameState is the phase of play the game is in.
+type GameState int
+
+const (
+	StateReady         GameState = iota // "READY!" pause before play
+	StatePlaying                        // player and ghosts move
+	StateLifeLost                       // freeze and death sequence
+	StateLevelComplete                  // board cleared, maze flashes
+	StateIntermission                   // cutscene between levels
+	StateGameOver                       // "GAME OVER" banner
 )
 
+func (s GameState) String() string {
+	switch s {
+	case StateReady:
+		return "Ready"
+	case StatePlaying:
+		return "Playing"
+	case StateLifeLost:
+		return "LifeLost"
+	case StateLevelComplete:
+		return "LevelComplete"
+	case StateIntermission:
+		return "Intermission"
+	case StateGameOver:
+		return "GameOver"
+	default:
+		return "Unknown"
+	}
+}
+
+// State reports the current phase of play.
+func (g *Game) State() GameState {
+	return g.state
+}
+
+func (g *Game) setState(s GameState) {
+	g.state = s
+	g.stateTicks = 0
+}
+
+// step advances the game world by one update. It is driven by Update once
+// name entry and the leaderboard are out of the way, and by tests directly.
+func (g *Game) step() {
+	if g.frightenedUntilTick != 0 && g.tickCounter >= g.frightenedUntilTick {
+		g.endFrightened()
+	}
+	if g.paused {
+		g.holdTimers()
+		return
+	}
+	g.stateTicks++
+	g.updatePopups()
+	switch g.state {
+	case StateReady:
+		if g.stateTicks >= readyTicks {
+			g.setState(StatePlaying)
+			// The house timeout counts from the start of play
+			g.lastPelletTick = g.tickCounter
+		}
+	case StatePlaying:
+		if g.hitStopActive() {
+			g.updateHitStop()
+			return
+		}
+		g.updateGhostMode()
+		g.updateGhostHouse()
+		g.updatePlayerMovement()
+		g.handlePelletCollision()
+		if g.state != StatePlaying {
+			return
+		}
+		g.updateGhosts()
+		g.checkPlayerGhostCollision()
+	case StateLifeLost:
+		g.updateDeath()
+	case StateLevelComplete:
+		if g.stateTicks >= levelCompleteTicks {
+			g.finishLevel()
+		}
+	case StateIntermission:
+		g.updateIntermission()
+	case StateGameOver:
+		if g.stateTicks >= gameOverTicks {
+			g.showingLeaderboard = true
+		}
+	}
+}
+
+// holdTimers runs one paused tick. The play deadlines are pushed back so
+// time spent paused costs nothing once play resumes.
+func (g *Game) holdTimers() {
+	if g.frightenedUntilTick != 0 {
+		g.frightenedUntilTick++
+	}
+	if g.fruitActive() {
+		g.fruitUntilTick++
+	}
+	if g.hitStopActive() {
+		g.hitStopUntilTick++
+	}
+	for i := range g.popups {
+		g.popups[i].untilTick++
+	}
+	g.lastPelletTick++
+}
+
+// startLevel refills the maze for the given level and puts everyone back at
+// the start, with the scatter/chase schedule and house counters restarted.
+func (g *Game) startLevel(level int) {
+	g.level = level
+	g.modePhase = 0
+	g.modeTicks = 0
+	g.houseGlobalDots = -1
+	if src := g.opts.mazeFor(level); src != g.mazeSource {
+		g.useMaze(src) // the playlist moves on to another board
+	}
+	g.tileMap.ResetPellets()
+	g.pelletsEaten = 0
+	for _, gh := range g.ghosts {
+		gh.DotCounter = 0
+	}
+	g.resetPositions()
+	g.setState(StateReady)
+}
+
 func (g *Game) isFrightened() bool {
 	return g.frightenedUntilTick > g.tickCounter
 }
 
-// reverseAllGhosts reverses the direction of all ghosts when entering frightened mode
+// reverseAllGhosts reverses the direction of all ghosts in the maze. It is
+// used whenever the ghosts change mode, including entering frightened mode.
 func (g *Game) reverseAllGhosts() {
 	for _, gh := range g.ghosts {
-		gh.CurrentDir = reverseDir(gh.CurrentDir)
+		if gh.State.IsRoaming() || gh.State == entities.GhostFrightened {
+			gh.CurrentDir = reverseDir(gh.CurrentDir)
+		}
 	}
 }
 
 func (g *Game) resetPositions() {
-	// Reset player
-	g.player.X = float64(14*tileSize + tileSize/2)
-	g.player.Y = float64(26*tileSize + tileSize/2)
-	g.player.CurrentDir = entities.DirNone
-	g.player.DesiredDir = entities.DirNone
+	g.placePlayerAtSpawn()
 	// Clear frightened state on life loss
 	g.frightenedUntilTick = 0
 	g.ghostEatCombo = 0
+	g.resetFrightenedRNG()
+	g.resetBrainRNG()
+	g.resetFruitRNG()
+	g.fruitUntilTick = 0
+	g.popups = nil
+	g.hitStopUntilTick = 0
+	g.hitStopGhost = nil
 	// Reset ghosts to house
-	positions := [][2]int{{13, 14}, {14, 14}, {13, 15}, {14, 15}}
 	for i, gh := range g.ghosts {
-		ox, oy := g.nearestOpenTile(positions[i][0], positions[i][1])
-		gh.X = float64(ox*tileSize + tileSize/2)
-		gh.Y = float64(oy*tileSize + tileSize/2)
-		gh.CurrentDir = entities.DirLeft
+		g.spawnGhost(gh, i)
 	}
+	g.lastPelletTick = g.tickCounter
+}
+
+// placePlayerAtSpawn puts the player, standing still, on the maze's spawn.
+func (g *Game) placePlayerAtSpawn() {
+	p := g.tileMap.Layout.Player
+	g.player.X, g.player.Y = g.cellCenter(p.X, p.Y)
+	g.player.CurrentDir = entities.DirNone
+	g.player.DesiredDir = entities.DirNone
 }
 
 // nearestOpenTile returns the nearest non-wall tile from a starting grid coordinate.
@@ -58,25 +192,3 @@ func (g *Game) nearestOpenTile(x, y int) (int, int) {
 	// fallback to original
 	return x, y
 }
-
-// nearestCorridorTile finds a non-wall, non-empty corridor (i.e., avoids large empty blue regions)
-func (g *Game) nearestCorridorTile(x, y int) (int, int) {
-	if !g.tileMap.IsWall(x, y) && g.tileMap.Tiles[y][x] != tm.TileEmpty {
-		return x, y
-	}
-	maxR := 8
-	for r := 1; r <= maxR; r++ {
-		for dy := -r; dy <= r; dy++ {
-			for dx := -r; dx <= r; dx++ {
-				nx, ny := x+dx, y+dy
-				if nx < 0 || ny < 0 || nx >= g.tileMap.Width || ny >= g.tileMap.Height {
-					continue
-				}
-				if !g.tileMap.IsWall(nx, ny) && g.tileMap.Tiles[ny][nx] != tm.TileEmpty {
-					return nx, ny
-				}
-			}
-		}
-	}
-	return g.nearestOpenTile(x, y)
-}
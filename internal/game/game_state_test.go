@@ -0,0 +1,123 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+// advance runs n updates of the game world, as Update does once play has
+// started.
+func advance(g *Game, n int) {
+	for i := 0; i < n; i++ {
+		g.tickCounter++
+		g.step()
+	}
+}
+
+func TestReadyPauseBeforePlay(t *testing.T) {
+	g := New()
+	if g.State() != StateReady {
+		t.Fatalf("new game should start in Ready, got %v", g.State())
+	}
+	placePlayer(g, 14, 26, entities.DirNone)
+	g.player.DesiredDir = entities.DirLeft
+	x := g.player.X
+	advance(g, readyTicks-1)
+	if g.State() != StateReady || g.player.X != x {
+		t.Fatalf("player should wait during Ready, state %v x %.1f", g.State(), g.player.X)
+	}
+	advance(g, 1)
+	if g.State() != StatePlaying {
+		t.Fatalf("expected Playing after the Ready pause, got %v", g.State())
+	}
+	advance(g, 1)
+	if g.player.X == x {
+		t.Fatal("player should move once playing")
+	}
+}
+
+func TestLifeLostSequence(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	advance(g, readyTicks)
+	red := g.ghostOfKind(entities.GhostRed)
+	red.X, red.Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+	if g.State() != StateLifeLost {
+		t.Fatalf("expected LifeLost after being caught, got %v", g.State())
+	}
+	rx, ry := red.X, red.Y
+	advance(g, lifeLostFreezeTicks)
+	if red.X != rx || red.Y != ry || g.deathProgress() != 0 {
+		t.Fatal("everything should freeze before the death sequence")
+	}
+	advance(g, deathTicks-1)
+	if g.State() != StateLifeLost || g.deathProgress() >= 1 {
+		t.Fatalf("death sequence should still be running, state %v", g.State())
+	}
+	advance(g, 1)
+	if g.State() != StateReady {
+		t.Fatalf("expected Ready for the next life, got %v", g.State())
+	}
+	if px, py := g.playerGrid(); px != 14 || py != 26 {
+		t.Fatalf("player should be back at the start, got %d,%d", px, py)
+	}
+}
+
+func TestGameOverAfterLastLife(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	g.lives = 1
+	advance(g, readyTicks)
+	g.ghosts[0].X, g.ghosts[0].Y = g.player.X, g.player.Y
+	g.checkPlayerGhostCollision()
+	advance(g, lifeLostFreezeTicks+deathTicks)
+	if g.State() != StateGameOver {
+		t.Fatalf("expected GameOver, got %v", g.State())
+	}
+	if g.showingLeaderboard {
+		t.Fatal("leaderboard should wait for the game over banner")
+	}
+	advance(g, gameOverTicks)
+	if !g.showingLeaderboard {
+		t.Fatal("leaderboard should follow the game over banner")
+	}
+}
+
+func TestLevelCompleteAdvancesLevel(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	full := g.tileMap.PelletsRemaining()
+	advance(g, readyTicks)
+	eatPelletsUntil(g, 1)
+	// Put the player on the last pellet
+	for y := 0; y < g.tileMap.Height; y++ {
+		for x := 0; x < g.tileMap.Width; x++ {
+			if t := g.tileMap.Tiles[y][x]; t == tm.TilePellet || t == tm.TilePower {
+				placePlayer(g, x, y, entities.DirNone)
+			}
+		}
+	}
+	g.handlePelletCollision()
+	if g.State() != StateLevelComplete {
+		t.Fatalf("expected LevelComplete on an empty board, got %v", g.State())
+	}
+	advance(g, levelCompleteTicks)
+	if g.State() != StateReady {
+		t.Fatalf("expected Ready after the flash, got %v", g.State())
+	}
+	if got := g.tileMap.PelletsRemaining(); got != full {
+		t.Fatalf("board should be refilled, got %d of %d pellets", got, full)
+	}
+	if g.level != 2 {
+		t.Fatalf("expected level 2 after clearing the board, got %d", g.level)
+	}
+	if g.modePhase != 0 || g.modeTicks != 0 {
+		t.Fatalf("mode schedule should restart, got phase %d tick %d", g.modePhase, g.modeTicks)
+	}
+	if px, py := g.playerGrid(); px != 14 || py != 26 {
+		t.Fatalf("player should be back at the start, got %d,%d", px, py)
+	}
+}
@@ -0,0 +1,174 @@
+package game
+
+import "pacman/internal/entities"
+
+const (
+	// pinkLookAhead is how many tiles ahead of the player the pink ghost aims.
+	pinkLookAhead = 4
+	// cyanPivotAhead is how many tiles ahead of the player the cyan ghost's
+	// flanking vector is anchored.
+	cyanPivotAhead = 2
+	// orangeShyRadius is the distance (in tiles) under which the orange ghost
+	// gives up the chase and heads for its corner instead.
+	orangeShyRadius = 8
+)
+
+// ghostSpawnKinds maps the spawn slots used in New/resetPositions to ghost
+// personalities.
+var ghostSpawnKinds = []entities.GhostKind{
+	entities.GhostRed,
+	entities.GhostPink,
+	entities.GhostCyan,
+	entities.GhostOrange,
+}
+
+// ghostDecisionOrder is the order in which directions are considered when
+// several are equally good; it matches the arcade's up, left, down, right.
+var ghostDecisionOrder = []entities.Direction{entities.DirUp, entities.DirLeft, entities.DirDown, entities.DirRight}
+
+func (g *Game) ghostGrid(gh *entities.Ghost) (int, int) {
+	return int(gh.X) / tileSize, int(gh.Y) / tileSize
+}
+
+// ClassicTarget returns the tile a ghost's arcade personality aims for: its
+// home corner while scattering, otherwise its chase target.
+func ClassicTarget(w WorldView, gh entities.Ghost) (int, int) {
+	if gh.State == entities.GhostScatter {
+		return ScatterTarget(w, gh.Kind)
+	}
+	return chaseTarget(w, gh)
+}
+
+// chaseTarget returns the tile the ghost is currently aiming for based on its
+// personality. Targets may lie outside the maze; they only steer direction
+// choices and are never reached.
+func chaseTarget(w WorldView, gh entities.Ghost) (int, int) {
+	px, py := w.PlayerTile()
+	switch gh.Kind {
+	case entities.GhostPink:
+		return tilesAheadOfPlayer(w, pinkLookAhead)
+	case entities.GhostCyan:
+		pivotX, pivotY := tilesAheadOfPlayer(w, cyanPivotAhead)
+		rx, ry := px, py
+		for _, other := range w.Ghosts() {
+			if other.Kind == entities.GhostRed {
+				rx, ry = w.GhostTile(other)
+				break
+			}
+		}
+		return 2*pivotX - rx, 2*pivotY - ry
+	case entities.GhostOrange:
+		gx, gy := w.GhostTile(gh)
+		dx, dy := px-gx, py-gy
+		if dx*dx+dy*dy < orangeShyRadius*orangeShyRadius {
+			return ScatterTarget(w, gh.Kind)
+		}
+		return px, py
+	default:
+		return px, py
+	}
+}
+
+// ScatterTarget returns the home corner of a ghost, just outside the maze.
+func ScatterTarget(w WorldView, kind entities.GhostKind) (int, int) {
+	switch kind {
+	case entities.GhostPink:
+		return 2, -3
+	case entities.GhostCyan:
+		return w.Width() - 1, w.Height()
+	case entities.GhostOrange:
+		return 0, w.Height()
+	default:
+		return w.Width() - 3, -3
+	}
+}
+
+// tilesAheadOfPlayer returns the tile n steps ahead of the player in the
+// direction it is travelling (or the player's own tile when standing still).
+func tilesAheadOfPlayer(w WorldView, n int) (int, int) {
+	px, py := w.PlayerTile()
+	dx, dy := entities.DirDelta(w.PlayerDir())
+	return px + dx*n, py + dy*n
+}
+
+func (g *Game) ghostOfKind(kind entities.GhostKind) *entities.Ghost {
+	for _, gh := range g.ghosts {
+		if gh.Kind == kind {
+			return gh
+		}
+	}
+	return nil
+}
+
+// DirectionToward picks the option whose next tile is closest to the target
+// by straight-line distance; ties go to the earliest option.
+func DirectionToward(w WorldView, gh entities.Ghost, options []entities.Direction, tx, ty int) entities.Direction {
+	gx, gy := w.GhostTile(gh)
+	best := entities.DirNone
+	bestDist := -1
+	for _, d := range options {
+		dx, dy := entities.DirDelta(d)
+		nx, ny := gx+dx, gy+dy
+		dist := (nx-tx)*(nx-tx) + (ny-ty)*(ny-ty)
+		if bestDist < 0 || dist < bestDist {
+			best = d
+			bestDist = dist
+		}
+	}
+	return best
+}
+
+// ghostOptions lists the directions a ghost on tile (gx, gy) may take, in
+// decision order. Ghosts never reverse unless the only way out is back the
+// way they came, and roaming ghosts never turn up on no-up tiles.
+func (g *Game) ghostOptions(gh *entities.Ghost, gx, gy int) []entities.Direction {
+	noUp := gh.State.IsRoaming() && g.tileMap.IsNoUp(gx, gy)
+	options := make([]entities.Direction, 0, len(ghostDecisionOrder))
+	for _, d := range ghostDecisionOrder {
+		if isReverse(gh.CurrentDir, d) || (noUp && d == entities.DirUp) {
+			continue
+		}
+		if _, _, ok := g.openNeighbour(gx, gy, d); ok {
+			options = append(options, d)
+		}
+	}
+	if len(options) == 0 {
+		if d := g.deadEndDirection(gh, gx, gy); d != entities.DirNone {
+			options = append(options, d)
+		}
+	}
+	return options
+}
+
+// directionToTarget picks, among the ghost's options, the one closest to
+// the target tile.
+func (g *Game) directionToTarget(gh *entities.Ghost, gx, gy, tx, ty int) entities.Direction {
+	return DirectionToward(g.view(), *gh, g.ghostOptions(gh, gx, gy), tx, ty)
+}
+
+// deadEndDirection is used when every way forward is blocked: turning back
+// is the only option left.
+func (g *Game) deadEndDirection(gh *entities.Ghost, gx, gy int) entities.Direction {
+	back := reverseDir(gh.CurrentDir)
+	if _, _, ok := g.openNeighbour(gx, gy, back); ok {
+		return back
+	}
+	return entities.DirNone
+}
+
+// openNeighbour returns the neighbouring tile in direction d, wrapping
+// horizontally through tunnels, and whether it can be entered.
+func (g *Game) openNeighbour(gx, gy int, d entities.Direction) (int, int, bool) {
+	dx, dy := entities.DirDelta(d)
+	nx, ny := gx+dx, gy+dy
+	if nx < 0 {
+		nx = g.tileMap.Width - 1
+	}
+	if nx >= g.tileMap.Width {
+		nx = 0
+	}
+	if ny < 0 || ny >= g.tileMap.Height {
+		return nx, ny, false
+	}
+	return nx, ny, !g.tileMap.IsWall(nx, ny)
+}
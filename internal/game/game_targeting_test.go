@@ -0,0 +1,92 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func placePlayer(g *Game, gx, gy int, dir entities.Direction) {
+	g.player.X, g.player.Y = g.cellCenter(gx, gy)
+	g.player.CurrentDir = dir
+}
+
+func placeGhost(g *Game, gh *entities.Ghost, gx, gy int, dir entities.Direction) {
+	gh.X, gh.Y = g.cellCenter(gx, gy)
+	gh.CurrentDir = dir
+}
+
+func TestGhostSpawnSlotsHavePersonalities(t *testing.T) {
+	g := New()
+	want := []entities.GhostKind{entities.GhostRed, entities.GhostPink, entities.GhostCyan, entities.GhostOrange}
+	if len(g.ghosts) != len(want) {
+		t.Fatalf("expected %d ghosts, got %d", len(want), len(g.ghosts))
+	}
+	for i, gh := range g.ghosts {
+		if gh.Kind != want[i] {
+			t.Fatalf("ghost %d: got kind %v, want %v", i, gh.Kind, want[i])
+		}
+	}
+}
+
+func TestChaseTargets(t *testing.T) {
+	g := New()
+	placePlayer(g, 14, 26, entities.DirLeft)
+	red := g.ghostOfKind(entities.GhostRed)
+	placeGhost(g, red, 6, 20, entities.DirLeft)
+
+	if x, y := chaseTarget(g.view(), *red); x != 14 || y != 26 {
+		t.Fatalf("red should target the player tile, got %d,%d", x, y)
+	}
+	if x, y := chaseTarget(g.view(), *g.ghostOfKind(entities.GhostPink)); x != 10 || y != 26 {
+		t.Fatalf("pink should target four tiles ahead, got %d,%d", x, y)
+	}
+	// Pivot is two tiles ahead (12,26); the vector from red (6,20) is doubled.
+	if x, y := chaseTarget(g.view(), *g.ghostOfKind(entities.GhostCyan)); x != 18 || y != 32 {
+		t.Fatalf("cyan should flank from red's position, got %d,%d", x, y)
+	}
+}
+
+func TestOrangeRetreatsWhenClose(t *testing.T) {
+	g := New()
+	placePlayer(g, 14, 26, entities.DirNone)
+	orange := g.ghostOfKind(entities.GhostOrange)
+
+	placeGhost(g, orange, 6, 5, entities.DirLeft)
+	if x, y := chaseTarget(g.view(), *orange); x != 14 || y != 26 {
+		t.Fatalf("far away orange should chase the player, got %d,%d", x, y)
+	}
+
+	placeGhost(g, orange, 12, 26, entities.DirLeft)
+	sx, sy := ScatterTarget(g.view(), entities.GhostOrange)
+	if x, y := chaseTarget(g.view(), *orange); x != sx || y != sy {
+		t.Fatalf("close orange should head for its corner %d,%d, got %d,%d", sx, sy, x, y)
+	}
+}
+
+func TestDirectionToTargetDoesNotReverse(t *testing.T) {
+	g := New()
+	gh := g.ghosts[0]
+	// Bottom corridor (row 26) is open left and right; walls above and below at x=5.
+	placeGhost(g, gh, 5, 26, entities.DirLeft)
+	if d := g.directionToTarget(gh, 5, 26, 27, 26); d != entities.DirLeft {
+		t.Fatalf("expected ghost to keep going left rather than reverse, got %v", d)
+	}
+	// With reversal excluded the ghost takes the closest remaining exit.
+	placeGhost(g, gh, 1, 26, entities.DirLeft)
+	if d := g.directionToTarget(gh, 1, 26, 27, 26); d != entities.DirUp {
+		t.Fatalf("expected ghost to turn up in the corner, got %v", d)
+	}
+}
+
+func TestGhostsStopOnEveryTileCenter(t *testing.T) {
+	g := New()
+	gh := g.ghosts[0]
+	placeGhost(g, gh, 5, 26, entities.DirLeft)
+	g.moveGhost(gh, 3*tileSize)
+	gx, gy := g.ghostGrid(gh)
+	cx, cy := g.cellCenter(gx, gy)
+	if gh.X != cx || gh.Y != cy {
+		t.Fatalf("ghost should end on a tile center after whole-tile moves, got %.2f,%.2f", gh.X, gh.Y)
+	}
+}
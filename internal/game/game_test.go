@@ -1,6 +1,7 @@
 package game
 
 import (
+	"pacman/internal/entities"
 	tm "pacman/internal/tilemap"
 	"testing"
 )
@@ -195,6 +196,8 @@ func TestHighScoreUpdatedOnGhostEatWhenFrightened(t *testing.T) {
 	g.tickCounter = 100
 	g.frightenedUntilTick = 200
 
+	g.ghosts[0].State = entities.GhostFrightened
+
 	// Place a ghost at player's position
 	g.ghosts[0].X = g.player.X
 	g.ghosts[0].Y = g.player.Y
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+)
+
+func TestGhostsSlowDownInTunnels(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	placeGhost(g, red, 2, 14, entities.DirLeft)
+	want := ghostSpeedPixelsPerUpdate * levelFor(1).tunnelSpeed
+	if got := g.ghostSpeed(red); got != want {
+		t.Fatalf("tunnel speed %.2f, want %.2f", got, want)
+	}
+	red.State = entities.GhostFrightened
+	frightened := g.ghostSpeed(red)
+	placeGhost(g, red, 6, 14, entities.DirLeft)
+	if got := g.ghostSpeed(red); frightened > got {
+		t.Fatalf("a tunnel should not speed up a frightened ghost, %.2f in it and %.2f outside", frightened, got)
+	}
+	placeGhost(g, red, 2, 14, entities.DirLeft)
+	red.State = entities.GhostEaten
+	if got := g.ghostSpeed(red); got <= ghostSpeedPixelsPerUpdate {
+		t.Fatalf("eyes should not slow down in tunnels, got %.2f", got)
+	}
+	red.State = entities.GhostChase
+	placeGhost(g, red, 6, 14, entities.DirLeft)
+	if got := g.ghostSpeed(red); got != ghostSpeedPixelsPerUpdate {
+		t.Fatalf("ghost outside the tunnel should move at full speed, got %.2f", got)
+	}
+}
+
+func TestNoUpIntersections(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	// (12,11) is open upward, but ghosts roaming past it may not take it.
+	placeGhost(g, red, 12, 11, entities.DirLeft)
+	for _, d := range g.ghostOptions(red, 12, 11) {
+		if d == entities.DirUp {
+			t.Fatal("roaming ghost should not turn up at a no-up tile")
+		}
+	}
+
+	red.State = entities.GhostFrightened
+	found := false
+	for _, d := range g.ghostOptions(red, 12, 11) {
+		found = found || d == entities.DirUp
+	}
+	if !found {
+		t.Fatal("frightened ghost should be allowed to turn up")
+	}
+}
@@ -0,0 +1,105 @@
+package game
+
+// levelSpec holds the tuning values that change from level to level.
+type levelSpec struct {
+	// playerSpeed and ghostSpeed scale the base movement speeds.
+	playerSpeed float64
+	ghostSpeed  float64
+	// frightenedTicks is how long a power pellet frightens the ghosts.
+	frightenedTicks int
+	// modeSchedule lists scatter/chase phase lengths in ticks, alternating and
+	// starting with scatter. The final phase never ends.
+	modeSchedule []int
+	// houseDotLimits is how many pellets each ghost (indexed by GhostKind)
+	// must count while in the house before it is released.
+	houseDotLimits [4]int
+	// houseTimeout releases the next ghost when the player has not eaten a
+	// pellet for this many ticks.
+	houseTimeout int
+	// elroyDots are the pellet counts at or below which the red ghost enters
+	// the first and second Cruise Elroy stage; elroySpeed are the matching
+	// multipliers on ghost speed.
+	elroyDots  [2]int
+	elroySpeed [2]float64
+	// tunnelSpeed is the ghost speed multiplier on tunnel tiles.
+	tunnelSpeed float64
+}
+
+// levelTable is indexed by level-1; levels past the end reuse the last entry.
+var levelTable = []levelSpec{
+	// Level 1
+	{
+		playerSpeed:     1.0,
+		ghostSpeed:      1.0,
+		frightenedTicks: frightenedDurationUpdates,
+		modeSchedule:    []int{seconds(7), seconds(20), seconds(7), seconds(20), seconds(5), seconds(20), seconds(5), 0},
+		houseDotLimits:  [4]int{0, 0, 30, 60},
+		houseTimeout:    seconds(4),
+		elroyDots:       [2]int{20, 10},
+		elroySpeed:      [2]float64{1.07, 1.13},
+		tunnelSpeed:     0.53,
+	},
+	// Levels 2-4
+	{
+		playerSpeed:     1.05,
+		ghostSpeed:      1.1,
+		frightenedTicks: 100,
+		modeSchedule:    []int{seconds(7), seconds(20), seconds(7), seconds(20), seconds(5), seconds(1033), 1, 0},
+		houseDotLimits:  [4]int{0, 0, 0, 50},
+		houseTimeout:    seconds(4),
+		elroyDots:       [2]int{30, 15},
+		elroySpeed:      [2]float64{1.06, 1.12},
+		tunnelSpeed:     0.53,
+	},
+	{
+		playerSpeed:     1.05,
+		ghostSpeed:      1.1,
+		frightenedTicks: 80,
+		modeSchedule:    []int{seconds(7), seconds(20), seconds(7), seconds(20), seconds(5), seconds(1033), 1, 0},
+		houseDotLimits:  [4]int{0, 0, 0, 0},
+		houseTimeout:    seconds(4),
+		elroyDots:       [2]int{40, 20},
+		elroySpeed:      [2]float64{1.06, 1.12},
+		tunnelSpeed:     0.53,
+	},
+	{
+		playerSpeed:     1.05,
+		ghostSpeed:      1.1,
+		frightenedTicks: 60,
+		modeSchedule:    []int{seconds(7), seconds(20), seconds(7), seconds(20), seconds(5), seconds(1033), 1, 0},
+		houseDotLimits:  [4]int{0, 0, 0, 0},
+		houseTimeout:    seconds(4),
+		elroyDots:       [2]int{40, 20},
+		elroySpeed:      [2]float64{1.06, 1.12},
+		tunnelSpeed:     0.53,
+	},
+	// Level 5 onwards
+	{
+		playerSpeed:     1.1,
+		ghostSpeed:      1.2,
+		frightenedTicks: 40,
+		modeSchedule:    []int{seconds(5), seconds(20), seconds(5), seconds(20), seconds(5), seconds(1037), 1, 0},
+		houseDotLimits:  [4]int{0, 0, 0, 0},
+		houseTimeout:    seconds(3),
+		elroyDots:       [2]int{40, 20},
+		elroySpeed:      [2]float64{1.05, 1.1},
+		tunnelSpeed:     0.53,
+	},
+}
+
+// levelFor returns the spec for a 1-based level number.
+func levelFor(level int) levelSpec {
+	i := level - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(levelTable) {
+		i = len(levelTable) - 1
+	}
+	return levelTable[i]
+}
+
+// seconds converts a duration in seconds to update ticks.
+func seconds(s int) int {
+	return s * updatesPerSecond
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+func TestLevelTableGetsHarder(t *testing.T) {
+	prev := levelFor(1)
+	if prev.frightenedTicks != frightenedDurationUpdates {
+		t.Fatalf("level 1 should keep the standard frightened duration, got %d", prev.frightenedTicks)
+	}
+	for level := 2; level <= len(levelTable)+1; level++ {
+		spec := levelFor(level)
+		if spec.playerSpeed < prev.playerSpeed || spec.ghostSpeed < prev.ghostSpeed {
+			t.Fatalf("level %d should not be slower than the one before", level)
+		}
+		if spec.frightenedTicks > prev.frightenedTicks {
+			t.Fatalf("level %d frightens ghosts for longer than the one before", level)
+		}
+		prev = spec
+	}
+}
+
+func TestSpeedsFollowTheLevel(t *testing.T) {
+	g := New()
+	red := g.ghostOfKind(entities.GhostRed)
+	placeGhost(g, red, 6, 26, entities.DirLeft)
+	p1, g1 := g.playerSpeed(), g.ghostSpeed(red)
+	g.level = 5
+	if g.playerSpeed() <= p1 || g.ghostSpeed(red) <= g1 {
+		t.Fatalf("level 5 should be faster: player %.2f -> %.2f, ghost %.2f -> %.2f", p1, g.playerSpeed(), g1, g.ghostSpeed(red))
+	}
+}
+
+func TestPowerPelletUsesLevelDuration(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	g := New()
+	g.level = 3
+	gx, gy := g.playerGrid()
+	g.tileMap.Tiles[gy][gx] = tm.TilePower
+	g.handlePelletCollision()
+	if got, want := g.frightenedUntilTick-g.tickCounter, levelFor(3).frightenedTicks; got != want {
+		t.Fatalf("frightened for %d ticks, want %d", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package game
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+// Options configures a game created with NewWithOptions.
+type Options struct {
+	Difficulty Difficulty
+	// GhostBrains maps ghosts to registered brain names (see
+	// RegisterGhostBrain). Ghosts without an entry use DefaultGhostBrain.
+	GhostBrains map[entities.GhostKind]string
+	// ExtraLifeScore is the score that awards the first extra life; zero
+	// disables extra lives.
+	ExtraLifeScore int
+	// ExtraLifeEvery awards another life every this many points after the
+	// first; zero makes the award one-time.
+	ExtraLifeEvery int
+	// Maze is the board to play on, e.g. from LoadMaze; nil plays the
+	// built-in maze. Each game plays on its own copy.
+	Maze *tm.TileMap
+	// Playlist rotates mazes by level, e.g. from LoadPlaylist. When set it
+	// takes the place of Maze.
+	Playlist Playlist
+}
+
+// DefaultOptions returns the options used when nothing is configured.
+func DefaultOptions() Options {
+	return Options{Difficulty: DifficultyNormal, ExtraLifeScore: 10000}
+}
+
+// OptionsFromEnv builds options from the environment:
+//
+//	PACMAN_DIFFICULTY=easy|normal|hard
+//	PACMAN_GHOST_BRAINS=red=classic,pink=random
+//	PACMAN_EXTRA_LIFE=10000        (0 disables extra lives)
+//	PACMAN_EXTRA_LIFE_EVERY=20000  (0 for a one-time award)
+//
+// Malformed values are reported and ignored.
+func OptionsFromEnv() Options {
+	opts := DefaultOptions()
+	if v := os.Getenv("PACMAN_DIFFICULTY"); v != "" {
+		if d, ok := ParseDifficulty(v); ok {
+			opts.Difficulty = d
+		} else {
+			log.Printf("pacman: ignoring unknown PACMAN_DIFFICULTY %q", v)
+		}
+	}
+	if v := os.Getenv("PACMAN_GHOST_BRAINS"); v != "" {
+		brains, err := ParseGhostBrains(v)
+		if err != nil {
+			log.Printf("pacman: ignoring PACMAN_GHOST_BRAINS: %v", err)
+		} else {
+			opts.GhostBrains = brains
+		}
+	}
+	if v := os.Getenv("PACMAN_EXTRA_LIFE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			opts.ExtraLifeScore = n
+		} else {
+			log.Printf("pacman: ignoring invalid PACMAN_EXTRA_LIFE %q", v)
+		}
+	}
+	if v := os.Getenv("PACMAN_EXTRA_LIFE_EVERY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			opts.ExtraLifeEvery = n
+		} else {
+			log.Printf("pacman: ignoring invalid PACMAN_EXTRA_LIFE_EVERY %q", v)
+		}
+	}
+	return opts
+}
+
+// mazeFor returns the configured maze for a level; nil means the built-in
+// maze.
+func (o Options) mazeFor(level int) *tm.TileMap {
+	if m := o.Playlist.MazeFor(level); m != nil {
+		return m
+	}
+	return o.Maze
+}
+
+// LoadMaze reads a maze text file (see tilemap.LoadFromReader for the
+// legend) sized for the game's tiles, for use as Options.Maze.
+func LoadMaze(path string) (*tm.TileMap, error) {
+	return tm.LoadFromFile(path, tileSize)
+}
+
+// ParseGhostBrains parses a comma-separated list of ghost=brain pairs, such
+// as "red=classic,pink=random".
+func ParseGhostBrains(s string) (map[entities.GhostKind]string, error) {
+	out := make(map[entities.GhostKind]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, brain, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected ghost=brain, got %q", pair)
+		}
+		kind, ok := entities.ParseGhostKind(strings.ToLower(strings.TrimSpace(name)))
+		if !ok {
+			return nil, fmt.Errorf("unknown ghost %q", name)
+		}
+		out[kind] = strings.TrimSpace(brain)
+	}
+	return out, nil
+}
+
+// resolveGhostBrains looks up the configured brain for every ghost, falling
+// back to the default brain for unknown names.
+func resolveGhostBrains(names map[entities.GhostKind]string) map[entities.GhostKind]GhostBrain {
+	out := make(map[entities.GhostKind]GhostBrain, len(ghostSpawnKinds))
+	for _, kind := range ghostSpawnKinds {
+		name := names[kind]
+		if name == "" {
+			name = DefaultGhostBrain
+		}
+		b, ok := lookupGhostBrain(name)
+		if !ok {
+			log.Printf("pacman: unknown ghost brain %q for %v ghost, using %q", name, kind, DefaultGhostBrain)
+			b, _ = lookupGhostBrain(DefaultGhostBrain)
+		}
+		out[kind] = b
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tm "pacman/internal/tilemap"
+)
+
+// PlaylistEntry plays one maze for a run of levels.
+type PlaylistEntry struct {
+	Maze   *tm.TileMap
+	Levels int // levels in a row on this maze; less than one counts as one
+}
+
+// Playlist is the rotation of mazes across levels, the way Ms. Pac-Man
+// changes boards: each entry is played for its levels in turn, and after the
+// last the rotation starts again from the first. The window is sized for the
+// first maze, so every maze in a playlist should be the same size.
+type Playlist []PlaylistEntry
+
+// MazeFor returns the maze played on a level, counting from one, or nil if
+// the playlist is empty.
+func (p Playlist) MazeFor(level int) *tm.TileMap {
+	total := 0
+	for _, e := range p {
+		total += e.levels()
+	}
+	if total == 0 {
+		return nil
+	}
+	n := (level - 1) % total
+	if n < 0 {
+		n += total
+	}
+	for _, e := range p {
+		if n < e.levels() {
+			return e.Maze
+		}
+		n -= e.levels()
+	}
+	return nil
+}
+
+func (e PlaylistEntry) levels() int {
+	if e.Levels < 1 {
+		return 1
+	}
+	return e.Levels
+}
+
+// LoadPlaylist reads a playlist file, one maze per line with the levels it
+// is played on and its file, relative to the playlist:
+//
+//	; comment
+//	1-2  mazes/classic.txt
+//	3-5  mazes/pink.txt
+//
+// The levels must follow on from each other starting at level one; after the
+// last the rotation repeats. The mazes must all be the same size.
+func LoadPlaylist(path string) (Playlist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var p Playlist
+	next := 1
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s: line %d: want levels and a maze file, such as 1-2 classic.txt", path, n)
+		}
+		first, last, err := parseLevels(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: %w", path, n, err)
+		}
+		if first != next {
+			return nil, fmt.Errorf("%s: line %d: levels start at %d, want %d", path, n, first, next)
+		}
+		next = last + 1
+		m, err := LoadMaze(filepath.Join(filepath.Dir(path), fields[1]))
+		if err != nil {
+			return nil, err
+		}
+		if len(p) > 0 && (m.Width != p[0].Maze.Width || m.Height != p[0].Maze.Height) {
+			return nil, fmt.Errorf("%s: line %d: %s is %dx%d, want %dx%d like the first maze", path, n, fields[1], m.Width, m.Height, p[0].Maze.Width, p[0].Maze.Height)
+		}
+		p = append(p, PlaylistEntry{Maze: m, Levels: last - first + 1})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(p) == 0 {
+		return nil, fmt.Errorf("%s: playlist has no mazes", path)
+	}
+	return p, nil
+}
+
+// parseLevels reads a level or a range of levels such as 3-5.
+func parseLevels(s string) (int, int, error) {
+	a, b, isRange := strings.Cut(s, "-")
+	first, err := strconv.Atoi(a)
+	last := first
+	if err == nil && isRange {
+		last, err = strconv.Atoi(b)
+	}
+	if err != nil || first < 1 || last < first {
+		return 0, 0, fmt.Errorf("bad levels %q", s)
+	}
+	return first, last, nil
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tm "pacman/internal/tilemap"
+)
+
+func TestPlaylistMazeFor(t *testing.T) {
+	a, b := tm.NewDefaultMap(tileSize), tm.NewDefaultMap(tileSize)
+	p := Playlist{{Maze: a, Levels: 2}, {Maze: b, Levels: 3}}
+	want := map[int]*tm.TileMap{1: a, 2: a, 3: b, 5: b, 6: a, 7: a, 8: b, 11: a}
+	for level, m := range want {
+		if p.MazeFor(level) != m {
+			t.Errorf("level %d got the wrong maze", level)
+		}
+	}
+	if (Playlist{}).MazeFor(1) != nil {
+		t.Error("an empty playlist has no maze")
+	}
+}
+
+func TestLoadPlaylist(t *testing.T) {
+	p, err := LoadPlaylist("../../assets/playlist.txt")
+	if err != nil {
+		t.Fatalf("LoadPlaylist: %v", err)
+	}
+	if len(p) != 2 || p[0].Levels != 2 || p[1].Levels != 3 {
+		t.Fatalf("unexpected playlist %+v", p)
+	}
+	if p[0].Maze.Palette != tm.DefaultPalette || p[1].Maze.Palette.Wall == tm.DefaultPalette.Wall {
+		t.Fatal("the second maze should bring its own wall colour")
+	}
+}
+
+func TestLoadPlaylistErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, s string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(s), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("big.txt", "#####\n#P..#\n#####\n")
+	write("small.txt", "####\n#P.#\n####\n")
+	cases := []struct {
+		name, src, want string
+	}{
+		{"empty", "; nothing\n", "playlist has no mazes"},
+		{"gap", "1-2 big.txt\n4 big.txt\n", "line 2: levels start at 4, want 3"},
+		{"late start", "2 big.txt\n", "levels start at 2, want 1"},
+		{"bad range", "1-x big.txt\n", `bad levels "1-x"`},
+		{"backwards", "3-1 big.txt\n", `bad levels "3-1"`},
+		{"no maze", "1-2\n", "want levels and a maze file"},
+		{"missing maze", "1 gone.txt\n", "gone.txt"},
+		{"sizes differ", "1 big.txt\n2 small.txt\n", "small.txt is 4x3, want 5x3"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := LoadPlaylist(write("list.txt", c.src))
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Fatalf("expected error containing %q, got %v", c.want, err)
+			}
+		})
+	}
+}
+
+func TestGameFollowsPlaylist(t *testing.T) {
+	t.Setenv("PACMAN_CONFIG_DIR", t.TempDir())
+	p, err := LoadPlaylist("../../assets/playlist.txt")
+	if err != nil {
+		t.Fatalf("LoadPlaylist: %v", err)
+	}
+	g := NewWithOptions(Options{Playlist: p})
+	if g.mazeSource != p[0].Maze || g.tileMap == p[0].Maze {
+		t.Fatal("level 1 should play on a copy of the first maze")
+	}
+	g.startLevel(3)
+	board := g.tileMap
+	if g.mazeSource != p[1].Maze || board.Palette != p[1].Maze.Palette {
+		t.Fatal("level 3 should move on to the second maze and its colours")
+	}
+	g.tileMap.EatPelletAt(1, 1)
+	g.startLevel(4)
+	if g.tileMap != board || g.tileMap.Tiles[1][1] != p[1].Maze.Tiles[1][1] {
+		t.Fatal("staying on a maze should keep the board and refill it")
+	}
+	g.startLevel(6)
+	if g.mazeSource != p[0].Maze {
+		t.Fatal("the rotation should start again after level 5")
+	}
+	g.startLevel(3)
+	g.Reset()
+	if g.mazeSource != p[0].Maze || g.level != 1 {
+		t.Fatal("Reset should go back to the first maze")
+	}
+}
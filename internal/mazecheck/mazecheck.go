@@ -0,0 +1,213 @@
+// Package mazecheck finds mistakes in a maze that would otherwise break the
+// game in quiet ways: pellets that can never be eaten, tunnels leading into
+// walls, a missing ghost house, spawns inside walls and ragged rows.
+package mazecheck
+
+import (
+	"fmt"
+
+	"pacman/internal/pathfinding"
+	tm "pacman/internal/tilemap"
+)
+
+// Check names the rule an Issue breaks.
+type Check string
+
+const (
+	CheckSize   Check = "size"   // rows and zones match the declared size
+	CheckSpawn  Check = "spawn"  // spawns are marked and not inside walls
+	CheckPellet Check = "pellet" // every pellet is reachable from the player spawn
+	CheckTunnel Check = "tunnel" // tunnels open on both side edges of a row
+	CheckHouse  Check = "house"  // a ghost house with a door exists and is closed
+)
+
+// Issue is one problem found in a maze. X and Y are the tile it concerns, or
+// -1 when it is not about a single tile.
+type Issue struct {
+	Check Check
+	X, Y  int
+	Msg   string
+}
+
+func (i Issue) Error() string {
+	if i.X < 0 || i.Y < 0 {
+		return fmt.Sprintf("%s: %s", i.Check, i.Msg)
+	}
+	return fmt.Sprintf("%d,%d: %s: %s", i.X, i.Y, i.Check, i.Msg)
+}
+
+// Validate runs every check on a maze and returns what it found, in the
+// order of the checks; a good maze gives none. A maze with the wrong shape
+// is not checked further.
+func Validate(m *tm.TileMap) []Issue {
+	if issues := checkSize(m); len(issues) > 0 {
+		return issues
+	}
+	var issues []Issue
+	issues = append(issues, checkSpawns(m)...)
+	issues = append(issues, checkPellets(m)...)
+	issues = append(issues, checkTunnels(m)...)
+	issues = append(issues, checkHouse(m)...)
+	return issues
+}
+
+func checkSize(m *tm.TileMap) []Issue {
+	if m.Width <= 0 || m.Height <= 0 {
+		return []Issue{{CheckSize, -1, -1, fmt.Sprintf("maze is %dx%d", m.Width, m.Height)}}
+	}
+	var issues []Issue
+	if len(m.Tiles) != m.Height {
+		issues = append(issues, Issue{CheckSize, -1, -1, fmt.Sprintf("maze has %d rows, want %d", len(m.Tiles), m.Height)})
+	}
+	if len(m.Zones) != len(m.Tiles) {
+		issues = append(issues, Issue{CheckSize, -1, -1, fmt.Sprintf("maze has %d zone rows, want %d", len(m.Zones), len(m.Tiles))})
+	}
+	for y, row := range m.Tiles {
+		if len(row) != m.Width {
+			issues = append(issues, Issue{CheckSize, -1, y, fmt.Sprintf("row %d has %d tiles, want %d", y, len(row), m.Width)})
+		}
+		if y < len(m.Zones) && len(m.Zones[y]) != m.Width {
+			issues = append(issues, Issue{CheckSize, -1, y, fmt.Sprintf("row %d has %d zones, want %d", y, len(m.Zones[y]), m.Width)})
+		}
+	}
+	return issues
+}
+
+// spot is a marked position with the name used in messages.
+type spot struct {
+	name string
+	p    *tm.Point
+}
+
+func checkSpawns(m *tm.TileMap) []Issue {
+	var issues []Issue
+	if m.Spawns.Player == nil {
+		issues = append(issues, Issue{CheckSpawn, -1, -1, "no player spawn"})
+	}
+	spots := []spot{{"player spawn", m.Spawns.Player}, {"fruit spot", m.Spawns.Fruit}}
+	for i, p := range m.Spawns.Ghosts {
+		spots = append(spots, spot{fmt.Sprintf("ghost %d spawn", i+1), p})
+	}
+	for _, s := range spots {
+		if s.p == nil {
+			continue
+		}
+		if s.p.X < 0 || s.p.Y < 0 || s.p.X >= m.Width || s.p.Y >= m.Height {
+			issues = append(issues, Issue{CheckSpawn, s.p.X, s.p.Y, s.name + " is outside the maze"})
+		} else if m.IsWall(s.p.X, s.p.Y) {
+			issues = append(issues, Issue{CheckSpawn, s.p.X, s.p.Y, s.name + " is inside a wall"})
+		}
+	}
+	return issues
+}
+
+func checkPellets(m *tm.TileMap) []Issue {
+	p := m.Spawns.Player
+	if p == nil || m.IsWall(p.X, p.Y) {
+		return nil // reported by the spawn check
+	}
+	field := pathfinding.NewDistanceField(m, pathfinding.Point{X: p.X, Y: p.Y})
+	var issues []Issue
+	for y, row := range m.Tiles {
+		for x, t := range row {
+			if (t == tm.TilePellet || t == tm.TilePower) && field.Distance(x, y) == pathfinding.Unreachable {
+				issues = append(issues, Issue{CheckPellet, x, y, "pellet cannot be reached from the player spawn"})
+			}
+		}
+	}
+	return issues
+}
+
+// checkTunnels looks at the openings in the edges of the maze and at the
+// tunnel zones. The game only wraps sideways, so every opening must be on a
+// side edge, reachable from the player spawn, marked as tunnel and matched by
+// an opening on the same row of the other side. Every run of tunnel tiles
+// must reach a side edge.
+func checkTunnels(m *tm.TileMap) []Issue {
+	var field *pathfinding.DistanceField
+	if p := m.Spawns.Player; p != nil && !m.IsWall(p.X, p.Y) {
+		field = pathfinding.NewDistanceField(m, pathfinding.Point{X: p.X, Y: p.Y})
+	}
+	var issues []Issue
+	last := m.Width - 1
+	for x := 0; x < m.Width; x++ {
+		for _, y := range []int{0, m.Height - 1} {
+			if !m.IsWall(x, y) && x != 0 && x != last {
+				issues = append(issues, Issue{CheckTunnel, x, y, "opening in the top or bottom edge; tunnels only wrap sideways"})
+			}
+		}
+	}
+	for y := 0; y < m.Height; y++ {
+		for _, x := range []int{0, last} {
+			if m.IsWall(x, y) {
+				continue
+			}
+			other := last - x
+			switch {
+			case field != nil && field.Distance(x, y) == pathfinding.Unreachable:
+				issues = append(issues, Issue{CheckTunnel, x, y, "opening in the edge cannot be reached from the player spawn"})
+			case !m.IsTunnel(x, y):
+				issues = append(issues, Issue{CheckTunnel, x, y, "opening in the edge is not marked as tunnel"})
+			case m.IsWall(other, y) || !m.IsTunnel(other, y):
+				issues = append(issues, Issue{CheckTunnel, x, y, "tunnel has no exit on the opposite edge"})
+			}
+		}
+		for x := 0; x < m.Width; x++ {
+			if !m.IsTunnel(x, y) || (x > 0 && m.IsTunnel(x-1, y)) {
+				continue
+			}
+			end := x
+			for end < last && m.IsTunnel(end+1, y) {
+				end++
+			}
+			if x != 0 && end != last {
+				issues = append(issues, Issue{CheckTunnel, x, y, "tunnel does not reach a side edge"})
+			}
+		}
+	}
+	return issues
+}
+
+// checkHouse looks for the ghost house: a door with open tiles above (the
+// exit) and below (the house), and a house that can only be left through the
+// door.
+func checkHouse(m *tm.TileMap) []Issue {
+	var doors []tm.Point
+	for y, row := range m.Tiles {
+		for x, t := range row {
+			if t == tm.TileDoor {
+				doors = append(doors, tm.Point{X: x, Y: y})
+			}
+		}
+	}
+	if len(doors) == 0 {
+		return []Issue{{CheckHouse, -1, -1, "no ghost house door"}}
+	}
+	var issues []Issue
+	for _, d := range doors {
+		if m.IsWall(d.X, d.Y-1) {
+			issues = append(issues, Issue{CheckHouse, d.X, d.Y, "door has no exit above it"})
+		}
+	}
+	p := m.Spawns.Player
+	if p == nil || m.IsWall(p.X, p.Y) {
+		return issues
+	}
+	// The house below the door must be sealed off from the player's corridors
+	field := pathfinding.NewDistanceField(m, pathfinding.Point{X: p.X, Y: p.Y})
+	inside := false
+	for _, d := range doors {
+		if m.IsWall(d.X, d.Y+1) {
+			continue
+		}
+		inside = true
+		if field.Distance(d.X, d.Y+1) != pathfinding.Unreachable {
+			issues = append(issues, Issue{CheckHouse, d.X, d.Y + 1, "ghost house is open to the maze"})
+			break
+		}
+	}
+	if !inside {
+		issues = append(issues, Issue{CheckHouse, doors[0].X, doors[0].Y, "no room below the door for the ghost house"})
+	}
+	return issues
+}
@@ -0,0 +1,167 @@
+package mazecheck
+
+import (
+	"strings"
+	"testing"
+
+	tm "pacman/internal/tilemap"
+)
+
+func load(t *testing.T, rows ...string) *tm.TileMap {
+	t.Helper()
+	m, err := tm.LoadFromReader(strings.NewReader(strings.Join(rows, "\n")), 16)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	return m
+}
+
+// goodMaze is a small board that passes every check.
+var goodMaze = []string{
+	"#########",
+	"#...1...#",
+	"#.##-##.#",
+	"#.#234#.#",
+	"#.#####.#",
+	"T...P...T",
+	"#########",
+}
+
+func TestValidateGoodMaze(t *testing.T) {
+	if issues := Validate(load(t, goodMaze...)); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestValidateShippedMazes(t *testing.T) {
+	for _, name := range []string{"classic.txt", "pink.txt"} {
+		m, err := tm.LoadFromFile("../../assets/mazes/"+name, 16)
+		if err != nil {
+			t.Fatalf("LoadFromFile: %v", err)
+		}
+		if issues := Validate(m); len(issues) != 0 {
+			t.Errorf("expected %s to pass, got %v", name, issues)
+		}
+	}
+	if issues := Validate(tm.NewDefaultMap(16)); len(issues) != 0 {
+		t.Errorf("expected the built-in maze to pass, got %v", issues)
+	}
+}
+
+func TestValidateFindsProblems(t *testing.T) {
+	cases := []struct {
+		name  string
+		rows  []string
+		check Check
+		x, y  int
+	}{
+		{"unreachable pellet", []string{
+			"#########",
+			"#...1...#",
+			"#.##-##.#",
+			"#.#234#.#",
+			"#.#####.#",
+			"T...P#.#T",
+			"#########",
+		}, CheckPellet, 6, 5},
+		{"unpaired tunnel", []string{
+			"#########",
+			"#...1...#",
+			"#.##-##.#",
+			"#.#234#.#",
+			"#.#####.#",
+			"T...P...#",
+			"#########",
+		}, CheckTunnel, 0, 5},
+		{"edge opening not a tunnel", []string{
+			"#########",
+			"#...1...#",
+			"#.##-##.#",
+			"#.#234#.#",
+			"#.#####.#",
+			" ...P...T",
+			"#########",
+		}, CheckTunnel, 0, 5},
+		{"walled-in space on the edge", []string{
+			"#########",
+			"#...1...#",
+			"#.##-##.#",
+			"#.#234#.#",
+			"#.#####.#",
+			"T...P...T",
+			"#########",
+			"         ",
+		}, CheckTunnel, 0, 7},
+		{"opening in the top edge", []string{
+			"###.#####",
+			"#...1...#",
+			"#.##-##.#",
+			"#.#234#.#",
+			"#.#####.#",
+			"T...P...T",
+			"#########",
+		}, CheckTunnel, 3, 0},
+		{"tunnel short of the edge", []string{
+			"#########",
+			"#...1...#",
+			"#.##-##.#",
+			"#.#234#.#",
+			"#.#####.#",
+			"T.TTP...T",
+			"#########",
+		}, CheckTunnel, 2, 5},
+		{"open house", []string{
+			"#########",
+			"#...1...#",
+			"#.##-##.#",
+			"#.#234 .#",
+			"#.#####.#",
+			"T...P...T",
+			"#########",
+		}, CheckHouse, 4, 3},
+		{"no player spawn", []string{
+			"#####",
+			"#.-.#",
+			"#####",
+		}, CheckSpawn, -1, -1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			issues := Validate(load(t, c.rows...))
+			for _, is := range issues {
+				if is.Check == c.check && is.X == c.x && is.Y == c.y {
+					return
+				}
+			}
+			t.Fatalf("expected a %s issue at %d,%d, got %v", c.check, c.x, c.y, issues)
+		})
+	}
+}
+
+func TestValidateNoHouse(t *testing.T) {
+	issues := Validate(load(t, "#####", "T.P.T", "#####"))
+	if len(issues) != 1 || issues[0].Check != CheckHouse {
+		t.Fatalf("expected only a missing house, got %v", issues)
+	}
+	if got := issues[0].Error(); got != "house: no ghost house door" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestValidateSpawnInWall(t *testing.T) {
+	m := load(t, goodMaze...)
+	m.Spawns.Fruit = &tm.Point{X: 0, Y: 0}
+	issues := Validate(m)
+	if len(issues) != 1 || issues[0].Error() != "0,0: spawn: fruit spot is inside a wall" {
+		t.Fatalf("expected the fruit spot in a wall, got %v", issues)
+	}
+}
+
+func TestValidateRaggedRows(t *testing.T) {
+	m := load(t, goodMaze...)
+	m.Tiles[2] = m.Tiles[2][:3]
+	issues := Validate(m)
+	if len(issues) != 1 || issues[0].Check != CheckSize || issues[0].Y != 2 {
+		t.Fatalf("expected a size issue on row 2, got %v", issues)
+	}
+}
@@ -0,0 +1,104 @@
+package pathfinding
+
+import (
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+// Unreachable is the distance reported for tiles with no path to the target.
+const Unreachable = -1
+
+// DistanceField holds the walking distance from every tile to one target,
+// so repeated "which way home?" queries cost a single lookup.
+type DistanceField struct {
+	m      *tm.TileMap
+	target Point
+	dist   []int
+}
+
+// NewDistanceField floods the map outward from target.
+func NewDistanceField(m *tm.TileMap, target Point) *DistanceField {
+	f := &DistanceField{m: m, target: target, dist: make([]int, m.Width*m.Height)}
+	for i := range f.dist {
+		f.dist[i] = Unreachable
+	}
+	if m.IsWall(target.X, target.Y) {
+		return f
+	}
+	f.dist[f.index(target)] = 0
+	queue := []Point{target}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range directions {
+			n, ok := Neighbour(m, p, d)
+			if !ok || f.dist[f.index(n)] != Unreachable {
+				continue
+			}
+			f.dist[f.index(n)] = f.dist[f.index(p)] + 1
+			queue = append(queue, n)
+		}
+	}
+	return f
+}
+
+// Target returns the tile the field leads to.
+func (f *DistanceField) Target() Point {
+	return f.target
+}
+
+// Distance returns the number of steps from (x, y) to the target, or
+// Unreachable.
+func (f *DistanceField) Distance(x, y int) int {
+	if x < 0 || y < 0 || x >= f.m.Width || y >= f.m.Height {
+		return Unreachable
+	}
+	return f.dist[f.index(Point{X: x, Y: y})]
+}
+
+// NextDirection returns the first step of a shortest path from (x, y) to
+// the target, or DirNone when already there or no path exists.
+func (f *DistanceField) NextDirection(x, y int) entities.Direction {
+	here := f.Distance(x, y)
+	if here <= 0 {
+		return entities.DirNone
+	}
+	for _, d := range directions {
+		n, ok := Neighbour(f.m, Point{X: x, Y: y}, d)
+		if ok && f.dist[f.index(n)] == here-1 {
+			return d
+		}
+	}
+	return entities.DirNone
+}
+
+func (f *DistanceField) index(p Point) int {
+	return p.Y*f.m.Width + p.X
+}
+
+// Cache memoizes distance fields per target for one map. Call Invalidate
+// after the map's walls change.
+type Cache struct {
+	m      *tm.TileMap
+	fields map[Point]*DistanceField
+}
+
+// NewCache returns an empty cache for m.
+func NewCache(m *tm.TileMap) *Cache {
+	return &Cache{m: m, fields: make(map[Point]*DistanceField)}
+}
+
+// Field returns the distance field for target, computing it on first use.
+func (c *Cache) Field(target Point) *DistanceField {
+	if f, ok := c.fields[target]; ok {
+		return f
+	}
+	f := NewDistanceField(c.m, target)
+	c.fields[target] = f
+	return f
+}
+
+// Invalidate drops every cached field.
+func (c *Cache) Invalidate() {
+	c.fields = make(map[Point]*DistanceField)
+}
@@ -0,0 +1,167 @@
+// Package pathfinding provides grid searches over a tilemap.TileMap: BFS,
+// A* and cached distance fields. Rows whose edge tiles are both open wrap
+// around horizontally, matching the maze's tunnels.
+package pathfinding
+
+import (
+	"container/heap"
+
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+// Point is a tile coordinate.
+type Point struct {
+	X, Y int
+}
+
+// directions is the neighbour order used by every search; on ties the
+// earlier direction wins, matching the ghosts' up, left, down, right.
+var directions = []entities.Direction{entities.DirUp, entities.DirLeft, entities.DirDown, entities.DirRight}
+
+// Neighbour returns the tile reached by stepping from p in direction d,
+// wrapping horizontally, and whether that tile can be entered.
+func Neighbour(m *tm.TileMap, p Point, d entities.Direction) (Point, bool) {
+	dx, dy := entities.DirDelta(d)
+	n := Point{X: p.X + dx, Y: p.Y + dy}
+	if n.X < 0 {
+		n.X = m.Width - 1
+	}
+	if n.X >= m.Width {
+		n.X = 0
+	}
+	if n.Y < 0 || n.Y >= m.Height || (dx == 0 && dy == 0) {
+		return n, false
+	}
+	return n, !m.IsWall(n.X, n.Y)
+}
+
+// BFS returns the shortest path from one tile to another, including both
+// ends, or nil if the target cannot be reached.
+func BFS(m *tm.TileMap, from, to Point) []Point {
+	if m.IsWall(from.X, from.Y) || m.IsWall(to.X, to.Y) {
+		return nil
+	}
+	prev := map[Point]Point{from: from}
+	queue := []Point{from}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p == to {
+			return walkBack(prev, from, to)
+		}
+		for _, d := range directions {
+			n, ok := Neighbour(m, p, d)
+			if !ok {
+				continue
+			}
+			if _, seen := prev[n]; seen {
+				continue
+			}
+			prev[n] = p
+			queue = append(queue, n)
+		}
+	}
+	return nil
+}
+
+// AStar returns the shortest path from one tile to another, including both
+// ends, or nil if the target cannot be reached. It explores fewer tiles than
+// BFS for single queries across the maze.
+func AStar(m *tm.TileMap, from, to Point) []Point {
+	if m.IsWall(from.X, from.Y) || m.IsWall(to.X, to.Y) {
+		return nil
+	}
+	prev := map[Point]Point{from: from}
+	cost := map[Point]int{from: 0}
+	open := &nodeQueue{{p: from, f: heuristic(m, from, to)}}
+	seq := 0
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(node)
+		if cur.p == to {
+			return walkBack(prev, from, to)
+		}
+		if cur.g > cost[cur.p] {
+			continue // stale entry
+		}
+		for _, d := range directions {
+			n, ok := Neighbour(m, cur.p, d)
+			if !ok {
+				continue
+			}
+			g := cost[cur.p] + 1
+			if old, seen := cost[n]; seen && old <= g {
+				continue
+			}
+			cost[n] = g
+			prev[n] = cur.p
+			seq++
+			heap.Push(open, node{p: n, g: g, f: g + heuristic(m, n, to), seq: seq})
+		}
+	}
+	return nil
+}
+
+// heuristic is the Manhattan distance, taking the shorter way around
+// horizontally so it stays admissible with wrap tunnels.
+func heuristic(m *tm.TileMap, a, b Point) int {
+	dx := abs(a.X - b.X)
+	if wrap := m.Width - dx; wrap < dx {
+		dx = wrap
+	}
+	return dx + abs(a.Y-b.Y)
+}
+
+func walkBack(prev map[Point]Point, from, to Point) []Point {
+	var path []Point
+	for p := to; p != from; p = prev[p] {
+		path = append(path, p)
+	}
+	path = append(path, from)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// StepDirection returns the direction that moves from a to the adjacent tile
+// b, accounting for wrap tunnels, or DirNone if they are not adjacent.
+func StepDirection(m *tm.TileMap, a, b Point) entities.Direction {
+	for _, d := range directions {
+		if n, _ := Neighbour(m, a, d); n == b {
+			return d
+		}
+	}
+	return entities.DirNone
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+type node struct {
+	p    Point
+	g, f int
+	seq  int // insertion order, keeps results deterministic on ties
+}
+
+type nodeQueue []node
+
+func (q nodeQueue) Len() int { return len(q) }
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].f != q[j].f {
+		return q[i].f < q[j].f
+	}
+	return q[i].seq < q[j].seq
+}
+func (q nodeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x interface{}) { *q = append(*q, x.(node)) }
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,121 @@
+package pathfinding
+
+import (
+	"testing"
+
+	"pacman/internal/entities"
+	tm "pacman/internal/tilemap"
+)
+
+// testMap builds a small map from ASCII rows: '#' wall, anything else open.
+func testMap(rows ...string) *tm.TileMap {
+	m := &tm.TileMap{Width: len(rows[0]), Height: len(rows), TileSize: 16}
+	m.Tiles = make([][]tm.Tile, m.Height)
+	for y, row := range rows {
+		m.Tiles[y] = make([]tm.Tile, m.Width)
+		for x := range row {
+			if row[x] == '#' {
+				m.Tiles[y][x] = tm.TileWall
+			}
+		}
+	}
+	return m
+}
+
+func TestBFSAndAStarFindShortestPath(t *testing.T) {
+	m := testMap(
+		"#######",
+		"#.....#",
+		"#.###.#",
+		"#...#.#",
+		"#######",
+	)
+	from, to := Point{X: 1, Y: 3}, Point{X: 5, Y: 3}
+	for name, search := range map[string]func(*tm.TileMap, Point, Point) []Point{"bfs": BFS, "astar": AStar} {
+		path := search(m, from, to)
+		if len(path) != 9 {
+			t.Fatalf("%s: expected 9 tiles around the wall, got %d: %v", name, len(path), path)
+		}
+		if path[0] != from || path[len(path)-1] != to {
+			t.Fatalf("%s: path should start and end at the endpoints, got %v", name, path)
+		}
+	}
+}
+
+func TestSearchUsesWrapTunnel(t *testing.T) {
+	m := testMap(
+		"#######",
+		"...#...",
+		"#######",
+	)
+	from, to := Point{X: 1, Y: 1}, Point{X: 5, Y: 1}
+	for name, search := range map[string]func(*tm.TileMap, Point, Point) []Point{"bfs": BFS, "astar": AStar} {
+		path := search(m, from, to)
+		if len(path) != 4 {
+			t.Fatalf("%s: expected to go through the tunnel in 4 tiles, got %v", name, path)
+		}
+	}
+	if d := StepDirection(m, Point{X: 0, Y: 1}, Point{X: 6, Y: 1}); d != entities.DirLeft {
+		t.Fatalf("stepping through the tunnel should be left, got %v", d)
+	}
+}
+
+func TestUnreachableTarget(t *testing.T) {
+	m := testMap(
+		"#####",
+		"#.#.#",
+		"#####",
+	)
+	if p := BFS(m, Point{X: 1, Y: 1}, Point{X: 3, Y: 1}); p != nil {
+		t.Fatalf("expected no BFS path, got %v", p)
+	}
+	if p := AStar(m, Point{X: 1, Y: 1}, Point{X: 3, Y: 1}); p != nil {
+		t.Fatalf("expected no A* path, got %v", p)
+	}
+	f := NewDistanceField(m, Point{X: 3, Y: 1})
+	if d := f.Distance(1, 1); d != Unreachable {
+		t.Fatalf("expected unreachable distance, got %d", d)
+	}
+	if d := f.NextDirection(1, 1); d != entities.DirNone {
+		t.Fatalf("expected no direction when unreachable, got %v", d)
+	}
+}
+
+func TestDistanceFieldLeadsHome(t *testing.T) {
+	m := testMap(
+		"#######",
+		"#.....#",
+		"#.###.#",
+		"#...#.#",
+		"#######",
+	)
+	home := Point{X: 5, Y: 3}
+	f := NewCache(m).Field(home)
+	p := Point{X: 1, Y: 3}
+	if d := f.Distance(p.X, p.Y); d != 8 {
+		t.Fatalf("expected distance 8, got %d", d)
+	}
+	for steps := 0; p != home; steps++ {
+		if steps > 8 {
+			t.Fatalf("following the field took too many steps, at %v", p)
+		}
+		next, ok := Neighbour(m, p, f.NextDirection(p.X, p.Y))
+		if !ok {
+			t.Fatalf("field pointed into a wall from %v", p)
+		}
+		p = next
+	}
+}
+
+func TestCacheReusesFields(t *testing.T) {
+	m := testMap("#...#")
+	c := NewCache(m)
+	a := c.Field(Point{X: 1, Y: 0})
+	if b := c.Field(Point{X: 1, Y: 0}); a != b {
+		t.Fatal("expected the cached field to be reused")
+	}
+	c.Invalidate()
+	if b := c.Field(Point{X: 1, Y: 0}); a == b {
+		t.Fatal("expected a fresh field after Invalidate")
+	}
+}
@@ -0,0 +1,111 @@
+package tilemap
+
+// House is the ghost house: the first tile of its door, the tile above the
+// door where ghosts leave and eaten ghosts return, and the middle of the
+// room below.
+type House struct {
+	Door, Exit, Center Point
+}
+
+// Layout is where everything starts in a maze. Marked spawns are used as
+// they are; anything not marked is placed around the ghost house the way the
+// classic board has it.
+type Layout struct {
+	Player Point
+	Ghosts [4]Point // red, pink, cyan, orange
+	Fruit  Point
+	House  House
+}
+
+// findLayout works out the layout from the door tiles and the markers.
+func (m *TileMap) findLayout() Layout {
+	var l Layout
+	door, ok := m.firstDoor()
+	if !ok {
+		// No house: everything meets in the middle
+		door = Point{X: m.Width / 2, Y: m.Height / 2}
+	}
+	h := House{Door: door, Exit: Point{X: door.X, Y: door.Y - 1}, Center: Point{X: door.X, Y: door.Y - 1}}
+	floor := door.Y
+	if top := door.Y + 1; ok && !m.IsWall(door.X, top) {
+		bottom := top
+		for !m.IsWall(door.X, bottom+1) {
+			bottom++
+		}
+		h.Center = Point{X: door.X, Y: (top + bottom) / 2}
+		floor = bottom + 1
+	}
+	l.House = h
+
+	// The fruit sits just under the house, the player starts there too
+	// unless marked; red waits at the exit and the others inside
+	l.Fruit = m.openOr(Point{X: door.X, Y: floor + 1}, h.Exit)
+	l.Player = l.Fruit
+	l.Ghosts = [4]Point{
+		h.Exit,
+		h.Center,
+		m.openOr(Point{X: h.Center.X - 2, Y: h.Center.Y}, h.Center),
+		m.openOr(Point{X: h.Center.X + 2, Y: h.Center.Y}, h.Center),
+	}
+
+	if p := m.Spawns.Player; p != nil {
+		l.Player = *p
+	}
+	if p := m.Spawns.Fruit; p != nil {
+		l.Fruit = *p
+	}
+	for i, p := range m.Spawns.Ghosts {
+		if p != nil {
+			l.Ghosts[i] = *p
+		}
+	}
+	return l
+}
+
+// InHouse reports whether p is in the ghost house: the room reached from
+// under the door without going through it. A room that opens onto the rest
+// of the maze is no house, so nothing is inside it.
+func (m *TileMap) InHouse(p Point) bool {
+	door, ok := m.firstDoor()
+	if !ok || m.IsWall(door.X, door.Y+1) {
+		return false
+	}
+	exit := Point{X: door.X, Y: door.Y - 1}
+	seen := map[Point]bool{{X: door.X, Y: door.Y + 1}: true}
+	queue := []Point{{X: door.X, Y: door.Y + 1}}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if c == exit {
+			return false
+		}
+		for _, d := range []Point{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}} {
+			n := Point{X: c.X + d.X, Y: c.Y + d.Y}
+			if !seen[n] && !m.IsWall(n.X, n.Y) {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return seen[p]
+}
+
+// firstDoor returns the first door tile in reading order.
+func (m *TileMap) firstDoor() (Point, bool) {
+	for y, row := range m.Tiles {
+		for x, t := range row {
+			if t == TileDoor {
+				return Point{X: x, Y: y}, true
+			}
+		}
+	}
+	return Point{}, false
+}
+
+// openOr returns p unless it is a wall, in which case it returns fallback.
+func (m *TileMap) openOr(p, fallback Point) Point {
+	if m.IsWall(p.X, p.Y) {
+		return fallback
+	}
+	return p
+}
@@ -0,0 +1,108 @@
+package tilemap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultMapLayout(t *testing.T) {
+	l := NewDefaultMap(16).Layout
+	want := Layout{
+		Player: Point{14, 26},
+		Ghosts: [4]Point{{13, 11}, {13, 14}, {11, 14}, {15, 14}},
+		Fruit:  Point{13, 17},
+		House:  House{Door: Point{13, 12}, Exit: Point{13, 11}, Center: Point{13, 14}},
+	}
+	if l != want {
+		t.Fatalf("default layout = %+v, want %+v", l, want)
+	}
+}
+
+func TestPlayerSpawnOnPellet(t *testing.T) {
+	m := NewDefaultMap(16)
+	if got := m.PelletsRemaining(); got != 230 {
+		t.Fatalf("default maze has %d pellets, want 230", got)
+	}
+	if p := m.Layout.Player; m.Tiles[p.Y][p.X] != TilePellet {
+		t.Fatal("the player should start on a pellet in the default maze")
+	}
+	_, err := LoadFromReader(strings.NewReader("#####\n#.pP#\n#####"), 16)
+	if err == nil || !strings.Contains(err.Error(), "marker 'P' appears more than once") {
+		t.Fatalf("p and P both mark the player spawn, got %v", err)
+	}
+}
+
+func TestLayoutWithoutMarkers(t *testing.T) {
+	src := strings.Join([]string{
+		"#########",
+		"#.......#",
+		"#.##-##.#",
+		"#.#   #.#",
+		"#.#   #.#",
+		"#.#####.#",
+		"#.......#",
+		"#########",
+	}, "\n")
+	m, err := LoadFromReader(strings.NewReader(src), 16)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	l := m.Layout
+	if l.House != (House{Door: Point{4, 2}, Exit: Point{4, 1}, Center: Point{4, 3}}) {
+		t.Errorf("house = %+v", l.House)
+	}
+	if l.Fruit != (Point{4, 6}) || l.Player != l.Fruit {
+		t.Errorf("fruit %v and player %v should sit under the house at 4,6", l.Fruit, l.Player)
+	}
+	if l.Ghosts != [4]Point{{4, 1}, {4, 3}, {4, 3}, {4, 3}} {
+		t.Errorf("ghosts = %v, want red at the exit and the rest inside", l.Ghosts)
+	}
+}
+
+func TestInHouse(t *testing.T) {
+	src := strings.Join([]string{
+		"#########",
+		"#.......#",
+		"#.##-##.#",
+		"#.#   #.#",
+		"#.#####.#",
+		"#.......#",
+		"#########",
+	}, "\n")
+	m, err := LoadFromReader(strings.NewReader(src), 16)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	for _, c := range []struct {
+		p    Point
+		want bool
+	}{{Point{3, 3}, true}, {Point{5, 3}, true}, {Point{4, 1}, false}, {Point{1, 3}, false}, {Point{4, 2}, false}} {
+		if got := m.InHouse(c.p); got != c.want {
+			t.Errorf("InHouse(%v) = %v, want %v", c.p, got, c.want)
+		}
+	}
+	// Knock a hole in the house wall: the room is part of the maze now
+	m.Tiles[3][2] = TileEmpty
+	if m.InHouse(Point{4, 3}) {
+		t.Error("an open room should have no inside")
+	}
+}
+
+func TestLayoutMarkersWin(t *testing.T) {
+	src := strings.Join([]string{
+		"#######",
+		"#P.F.2#",
+		"#######",
+	}, "\n")
+	m, err := LoadFromReader(strings.NewReader(src), 16)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	l := m.Layout
+	if l.Player != (Point{1, 1}) || l.Fruit != (Point{3, 1}) || l.Ghosts[1] != (Point{5, 1}) {
+		t.Fatalf("markers not used: %+v", l)
+	}
+	if c := m.Clone(); c.Layout != l {
+		t.Fatal("clone should keep the layout")
+	}
+}
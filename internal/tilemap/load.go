@@ -0,0 +1,210 @@
+package tilemap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Point is a tile position in the maze.
+type Point struct {
+	X, Y int
+}
+
+// Spawns holds the positions marked in a maze. A nil entry was not marked.
+type Spawns struct {
+	Player *Point
+	Ghosts [4]*Point // markers '1' to '4': red, pink, cyan, orange
+	Fruit  *Point
+}
+
+// commentPrefix starts a line that is ignored in maze files, and
+// colourPrefix a line that sets one of the maze's colours.
+const (
+	commentPrefix = ";"
+	colourPrefix  = "@"
+)
+
+// LoadFromFile reads a maze file. Tiled maps (.tmx, .tmj or .json, see
+// LoadTMX and LoadTMJ) are recognised by extension, with external tilesets
+// read relative to the map; anything else is read as text as described by
+// LoadFromReader.
+func LoadFromFile(path string, tileSize int) (*TileMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var m *TileMap
+	open := openRelative(filepath.Dir(path))
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tmx":
+		m, err = loadTMX(f, tileSize, open)
+	case ".tmj", ".json":
+		m, err = loadTMJ(f, tileSize, open)
+	default:
+		m, err = LoadFromReader(f, tileSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// LoadFromReader reads a maze drawn in text, one row of tiles per line:
+//
+//	#  wall
+//	.  pellet
+//	o  power pellet
+//	   (space) empty
+//	-  ghost house door
+//	T  tunnel: empty, slows ghosts
+//	_  empty, ghosts may not turn up
+//	:  pellet, ghosts may not turn up
+//	P  player spawn (empty)
+//	p  player spawn on a pellet
+//	1-4  red, pink, cyan and orange ghost spawns (empty)
+//	F  bonus fruit spot (empty)
+//
+// Lines starting with ';' are comments. Lines such as "@wall #ffb8ae" set
+// the wall, pellet or background colour; the rest come from DefaultPalette.
+// Short rows are padded with empty tiles, so trailing spaces may be trimmed.
+// Each marker may appear once.
+func LoadFromReader(r io.Reader, tileSize int) (*TileMap, error) {
+	var lines []string
+	var lineNos []int
+	palette := DefaultPalette
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+		if strings.HasPrefix(line, colourPrefix) {
+			f := strings.Fields(strings.TrimPrefix(line, colourPrefix))
+			if len(f) != 2 {
+				return nil, fmt.Errorf("line %d: want @name #rrggbb", n)
+			}
+			if err := palette.set(f[0], f[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			continue
+		}
+		lines = append(lines, line)
+		lineNos = append(lineNos, n)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	m, err := newMap(lines, lineNos, tileSize)
+	if err != nil {
+		return nil, err
+	}
+	m.Palette = palette
+	return m, nil
+}
+
+// newMap builds a map from maze rows. lineNos gives the source line of each
+// row for error messages; nil numbers the rows from one.
+func newMap(lines []string, lineNos []int, tileSize int) (*TileMap, error) {
+	grid, zones, spawns, err := parseMaze(lines, lineNos)
+	if err != nil {
+		return nil, err
+	}
+	return fromGrid(grid, zones, spawns, tileSize), nil
+}
+
+// fromGrid assembles a map from parsed tiles, zones and spawn markers.
+func fromGrid(grid [][]Tile, zones [][]Zone, spawns Spawns, tileSize int) *TileMap {
+	m := &TileMap{
+		Width:    len(grid[0]),
+		Height:   len(grid),
+		TileSize: tileSize,
+		Tiles:    grid,
+		Zones:    zones,
+		Spawns:   spawns,
+		Palette:  DefaultPalette,
+		initial:  copyTiles(grid),
+	}
+	m.pellets = m.countPellets()
+	m.Layout = m.findLayout()
+	return m
+}
+
+func parseMaze(lines []string, lineNos []int) ([][]Tile, [][]Zone, Spawns, error) {
+	var spawns Spawns
+	lineNo := func(y int) int {
+		if lineNos == nil {
+			return y + 1
+		}
+		return lineNos[y]
+	}
+	w := 0
+	for _, line := range lines {
+		if len(line) > w {
+			w = len(line)
+		}
+	}
+	if w == 0 {
+		return nil, nil, spawns, fmt.Errorf("maze has no tiles")
+	}
+	h := len(lines)
+	grid := make([][]Tile, h)
+	zones := make([][]Zone, h)
+	for y := 0; y < h; y++ {
+		grid[y] = make([]Tile, w)
+		zones[y] = make([]Zone, w)
+		for x := 0; x < len(lines[y]); x++ {
+			c := lines[y][x]
+			switch c {
+			case '#':
+				grid[y][x] = TileWall
+			case '.':
+				grid[y][x] = TilePellet
+			case 'o':
+				grid[y][x] = TilePower
+			case '-':
+				grid[y][x] = TileDoor
+			case ' ':
+				grid[y][x] = TileEmpty
+			case 'T':
+				grid[y][x] = TileEmpty
+				zones[y][x] = ZoneTunnel
+			case '_':
+				grid[y][x] = TileEmpty
+				zones[y][x] = ZoneNoUp
+			case ':':
+				grid[y][x] = TilePellet
+				zones[y][x] = ZoneNoUp
+			case 'P', 'p', 'F', '1', '2', '3', '4':
+				grid[y][x] = TileEmpty
+				if c == 'p' {
+					grid[y][x] = TilePellet
+				}
+				slot := spawns.marker(c)
+				if *slot != nil {
+					return nil, nil, spawns, fmt.Errorf("line %d column %d: marker %q appears more than once", lineNo(y), x+1, c)
+				}
+				*slot = &Point{X: x, Y: y}
+			default:
+				return nil, nil, spawns, fmt.Errorf("line %d column %d: unknown tile %q", lineNo(y), x+1, c)
+			}
+		}
+	}
+	return grid, zones, spawns, nil
+}
+
+// marker returns where the position of a spawn marker character is kept.
+func (s *Spawns) marker(c byte) **Point {
+	switch c {
+	case 'P', 'p':
+		return &s.Player
+	case 'F':
+		return &s.Fruit
+	default:
+		return &s.Ghosts[c-'1']
+	}
+}
@@ -0,0 +1,107 @@
+package tilemap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadFromReaderLegend(t *testing.T) {
+	src := strings.Join([]string{
+		"; a tiny board",
+		"#####",
+		"#.o-#",
+		"T_:P ",
+		"#1234",
+		"#F",
+	}, "\n")
+	m, err := LoadFromReader(strings.NewReader(src), 16)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	if m.Width != 5 || m.Height != 5 || m.TileSize != 16 {
+		t.Fatalf("unexpected size %dx%d tile %d", m.Width, m.Height, m.TileSize)
+	}
+	wantTiles := map[Point]Tile{
+		{0, 0}: TileWall, {1, 1}: TilePellet, {2, 1}: TilePower, {3, 1}: TileDoor,
+		{0, 2}: TileEmpty, {1, 2}: TileEmpty, {2, 2}: TilePellet, {3, 2}: TileEmpty,
+		{4, 4}: TileEmpty, {4, 3}: TileEmpty, {2, 4}: TileEmpty,
+	}
+	for p, want := range wantTiles {
+		if got := m.Tiles[p.Y][p.X]; got != want {
+			t.Errorf("tile %v = %v, want %v", p, got, want)
+		}
+	}
+	if !m.IsTunnel(0, 2) || !m.IsNoUp(1, 2) || !m.IsNoUp(2, 2) {
+		t.Error("expected tunnel and no-up zones on row 2")
+	}
+	if m.PelletsRemaining() != 3 {
+		t.Errorf("expected 3 pellets, got %d", m.PelletsRemaining())
+	}
+	if p := m.Spawns.Player; p == nil || *p != (Point{3, 2}) {
+		t.Errorf("player spawn = %v, want (3,2)", p)
+	}
+	for i, x := range []int{1, 2, 3, 4} {
+		if p := m.Spawns.Ghosts[i]; p == nil || *p != (Point{x, 3}) {
+			t.Errorf("ghost %d spawn = %v, want (%d,3)", i+1, p, x)
+		}
+	}
+	if p := m.Spawns.Fruit; p == nil || *p != (Point{1, 4}) {
+		t.Errorf("fruit = %v, want (1,4)", p)
+	}
+}
+
+func TestLoadFromReaderErrors(t *testing.T) {
+	cases := []struct {
+		name, src, want string
+	}{
+		{"empty", "; nothing here\n", "no tiles"},
+		{"unknown tile", "; header\n###\n#x#\n", "line 3 column 2: unknown tile 'x'"},
+		{"duplicate marker", "#P#\n#P#\n", "line 2 column 2: marker 'P' appears more than once"},
+		{"unknown colour", "@door #ffffff\n###\n", `line 1: unknown colour "door"`},
+		{"bad colour", "###\n@wall blue\n", `line 2: bad colour "blue"`},
+		{"colour without value", "@wall\n###\n", "line 1: want @name #rrggbb"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := LoadFromReader(strings.NewReader(c.src), 16)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Fatalf("expected error containing %q, got %v", c.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadFromFileClassicMaze(t *testing.T) {
+	m, err := LoadFromFile("../../assets/mazes/classic.txt", 16)
+	if err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	def := NewDefaultMap(16)
+	if m.Width != def.Width || m.Height != def.Height {
+		t.Fatalf("classic maze is %dx%d, built-in is %dx%d", m.Width, m.Height, def.Width, def.Height)
+	}
+	if m.Spawns.Player == nil || m.Spawns.Fruit == nil {
+		t.Fatal("classic maze should mark the player spawn and fruit")
+	}
+	for i, p := range m.Spawns.Ghosts {
+		if p == nil {
+			t.Fatalf("classic maze is missing ghost spawn %d", i+1)
+		}
+	}
+	if _, err := LoadFromFile("no-such-maze.txt", 16); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	m := NewDefaultMap(16)
+	m.EatPelletAt(1, 1)
+	c := m.Clone()
+	if c.Tiles[1][1] != TilePellet || c.PelletsRemaining() != m.PelletsRemaining()+1 {
+		t.Fatal("clone should start with a full board")
+	}
+	c.EatPelletAt(2, 1)
+	if m.Tiles[1][2] != TilePellet {
+		t.Fatal("eating on the clone changed the original")
+	}
+}
@@ -1,7 +1,7 @@
 package tilemap
 
 // defaultMaze approximates the classic 28x31 Pac-Man layout using ASCII.
-// Legend: '#' wall, '.' pellet, 'o' power pellet, ' ' empty
+// See LoadFromReader for the legend.
 var defaultMaze = []string{
 	"############################",
 	"#............##............#",
@@ -13,15 +13,15 @@ var defaultMaze = []string{
 	"#.####.##.########.##.####.#",
 	"#......##....##....##......#",
 	"######.##### ## #####.######",
-	"     #.##### ## #####.#     ",
-	"     #.##          ##.#     ",
-	"     #.## ###--### ##.#     ",
+	"######.##### ## #####.######",
+	"######.##   _1 _   ##.######",
+	"######.## ###--### ##.######",
 	"######.## #      # ##.######",
-	"      .   #      #   .      ",
+	"TTTTTT.   #3 2 4 #   .TTTTTT",
 	"######.## #      # ##.######",
-	"     #.## ######## ##.#     ",
-	"     #.##          ##.#     ",
-	"     #.## ######## ##.#     ",
+	"######.## ######## ##.######",
+	"######.##    F     ##.######",
+	"######.## ######## ##.######",
 	"######.## ######## ##.######",
 	"#............##............#",
 	"#.####.#####.##.#####.####.#",
@@ -29,9 +29,9 @@ var defaultMaze = []string{
 	"###.##.##.########.##.##.###",
 	"#......##....##....##......#",
 	"#.##########.##.##########.#",
-	"#..........................#",
+	"#...........:.p:...........#",
+	"############################",
+	"############################",
+	"############################",
 	"############################",
-	"                            ",
-	"                            ",
-	"                            ",
 }
@@ -0,0 +1,64 @@
+package tilemap
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Palette is the colours a maze is drawn in.
+type Palette struct {
+	Wall       color.RGBA
+	Pellet     color.RGBA // pellets and power pellets
+	Background color.RGBA
+}
+
+// DefaultPalette is the arcade's blue walls and white pellets on black.
+var DefaultPalette = Palette{
+	Wall:       color.RGBA{R: 33, G: 33, B: 255, A: 255},
+	Pellet:     color.RGBA{R: 255, G: 255, B: 255, A: 255},
+	Background: color.RGBA{A: 255},
+}
+
+// entry returns the colour a palette name refers to: wall, pellet or
+// background. It returns nil for any other name.
+func (p *Palette) entry(name string) *color.RGBA {
+	switch strings.ToLower(name) {
+	case "wall":
+		return &p.Wall
+	case "pellet":
+		return &p.Pellet
+	case "background":
+		return &p.Background
+	}
+	return nil
+}
+
+// ParseColor reads a colour written as #rrggbb, or as #aarrggbb the way
+// Tiled saves colours. Colours are always opaque; the alpha is ignored.
+func ParseColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) != 6 && len(hex) != 8 {
+		return color.RGBA{}, fmt.Errorf("bad colour %q, want #rrggbb", s)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("bad colour %q, want #rrggbb", s)
+	}
+	return color.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 255}, nil
+}
+
+// set sets the named colour from its written form.
+func (p *Palette) set(name, value string) error {
+	e := p.entry(name)
+	if e == nil {
+		return fmt.Errorf("unknown colour %q, want wall, pellet or background", name)
+	}
+	c, err := ParseColor(value)
+	if err != nil {
+		return err
+	}
+	*e = c
+	return nil
+}
@@ -0,0 +1,72 @@
+package tilemap
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	cases := map[string]color.RGBA{
+		"#ffb8ae":   {R: 255, G: 184, B: 174, A: 255},
+		"2121FF":    {R: 33, G: 33, B: 255, A: 255},
+		"#80000028": {B: 40, A: 255}, // Tiled's alpha is ignored
+	}
+	for s, want := range cases {
+		if got, err := ParseColor(s); err != nil || got != want {
+			t.Errorf("ParseColor(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "#fff", "#gggggg", "red"} {
+		if _, err := ParseColor(s); err == nil {
+			t.Errorf("ParseColor(%q) should fail", s)
+		}
+	}
+}
+
+func TestLoadFromReaderPalette(t *testing.T) {
+	src := "; colours\n@wall #ffb8ae\n@Pellet #dedeff\n###\n#.#\n###\n"
+	m, err := LoadFromReader(strings.NewReader(src), 16)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	want := DefaultPalette
+	want.Wall = color.RGBA{R: 255, G: 184, B: 174, A: 255}
+	want.Pellet = color.RGBA{R: 222, G: 222, B: 255, A: 255}
+	if m.Palette != want {
+		t.Fatalf("palette = %+v, want %+v", m.Palette, want)
+	}
+	if m.Height != 3 {
+		t.Fatalf("colour lines should not be rows, got height %d", m.Height)
+	}
+	if NewDefaultMap(16).Palette != DefaultPalette {
+		t.Fatal("the built-in maze should use the default palette")
+	}
+	if m.Clone().Palette != want {
+		t.Fatal("clone should keep the palette")
+	}
+}
+
+func TestTiledPalette(t *testing.T) {
+	tmx := `<map width="1" height="1" tilewidth="8" tileheight="8" backgroundcolor="#000028">` +
+		`<properties><property name="wall" type="color" value="#ffffb8ae"/><property name="author" value="me"/></properties>` +
+		tiledTiles + `<layer name="maze"><data encoding="csv">1</data></layer></map>`
+	m, err := LoadTMX(strings.NewReader(tmx), 16)
+	if err != nil {
+		t.Fatalf("LoadTMX: %v", err)
+	}
+	if m.Palette.Background != (color.RGBA{B: 40, A: 255}) || m.Palette.Wall != (color.RGBA{R: 255, G: 184, B: 174, A: 255}) {
+		t.Errorf("palette = %+v", m.Palette)
+	}
+	if m.Palette.Pellet != DefaultPalette.Pellet {
+		t.Error("unset colours should keep the default")
+	}
+
+	tmj := `{"width": 1, "height": 1, "tilewidth": 8, "tileheight": 8,
+  "properties": [{"name": "pellet", "type": "color", "value": "not a colour"}],
+  "tilesets": [{"firstgid": 1, "tiles": [{"id": 0, "class": "wall"}]}],
+  "layers": [{"type": "tilelayer", "name": "maze", "data": [1]}]}`
+	if _, err := LoadTMJ(strings.NewReader(tmj), 16); err == nil || !strings.Contains(err.Error(), `map property "pellet": bad colour`) {
+		t.Fatalf("expected a bad colour error, got %v", err)
+	}
+}
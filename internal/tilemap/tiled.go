@@ -0,0 +1,635 @@
+package tilemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Maps made in the Tiled editor (https://www.mapeditor.org) say what each
+// tile is through their tilesets: every tile used needs its class (called
+// type before Tiled 1.9), or else a string property named "tile", set to one
+// of the names in tiledTileKinds. Objects in object layers are recognised by
+// class:
+//
+//	player  the player spawn
+//	ghost   a ghost spawn, named red, pink, cyan or orange
+//	fruit   the bonus fruit spot
+//	tunnel  tiles under the object are tunnel
+//	noup    tiles under the object bar ghosts from turning up
+//
+// Objects without a class are ignored. Spawns use the tile under the
+// object's centre, which is left empty as with the text markers; zones
+// cover every tile the object overlaps.
+//
+// The map's background colour is the maze background, and map properties
+// named wall, pellet or background (colour or string) set those colours.
+
+// tiledTileKinds maps tile class names to tiles.
+var tiledTileKinds = map[string]Tile{
+	"empty":  TileEmpty,
+	"wall":   TileWall,
+	"pellet": TilePellet,
+	"power":  TilePower,
+	"door":   TileDoor,
+}
+
+// tiledGhostNames maps ghost object names to their spawn slot.
+var tiledGhostNames = map[string]int{"red": 0, "pink": 1, "cyan": 2, "orange": 3}
+
+// tiledGIDMask strips the flip and rotation flags from a global tile ID.
+const tiledGIDMask = 0x0fffffff
+
+// opener opens a file named relative to the map being loaded.
+type opener func(name string) (io.ReadCloser, error)
+
+func openRelative(dir string) opener {
+	return func(name string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, name))
+	}
+}
+
+// tiledMap is a Tiled map reduced to what the loader needs, whichever format
+// it came from.
+type tiledMap struct {
+	width, height   int
+	tileW, tileH    int
+	tilesets        []tiledTileset
+	layers          []tiledLayer
+	objects         []tiledObject
+	colours         [][2]string // palette name and value, applied in order
+	infinite, group bool
+}
+
+type tiledTileset struct {
+	name     string
+	firstGID uint32
+	kinds    map[uint32]string // local tile ID to class
+}
+
+type tiledLayer struct {
+	name string
+	gids []uint32
+}
+
+type tiledObject struct {
+	name, class string
+	x, y, w, h  float64
+	tile        bool // tile objects are anchored at their bottom left
+}
+
+// LoadTMX reads a map saved by Tiled in its XML format. Tilesets must be
+// embedded; use LoadFromFile for maps with external tilesets.
+func LoadTMX(r io.Reader, tileSize int) (*TileMap, error) {
+	return loadTMX(r, tileSize, nil)
+}
+
+// LoadTMJ reads a map saved by Tiled in its JSON format. Tilesets must be
+// embedded; use LoadFromFile for maps with external tilesets.
+func LoadTMJ(r io.Reader, tileSize int) (*TileMap, error) {
+	return loadTMJ(r, tileSize, nil)
+}
+
+func loadTMX(r io.Reader, tileSize int, open opener) (*TileMap, error) {
+	var x tmxMap
+	if err := xml.NewDecoder(r).Decode(&x); err != nil {
+		return nil, fmt.Errorf("reading TMX: %w", err)
+	}
+	t, err := x.toTiled(open)
+	if err != nil {
+		return nil, err
+	}
+	return t.build(tileSize)
+}
+
+func loadTMJ(r io.Reader, tileSize int, open opener) (*TileMap, error) {
+	var j tmjMap
+	if err := json.NewDecoder(r).Decode(&j); err != nil {
+		return nil, fmt.Errorf("reading TMJ: %w", err)
+	}
+	t, err := j.toTiled(open)
+	if err != nil {
+		return nil, err
+	}
+	return t.build(tileSize)
+}
+
+// build turns the Tiled map into a TileMap: tile layers are stacked in order,
+// and object layers supply the spawns and zones.
+func (t *tiledMap) build(tileSize int) (*TileMap, error) {
+	switch {
+	case t.infinite:
+		return nil, fmt.Errorf("infinite maps are not supported")
+	case t.group:
+		return nil, fmt.Errorf("layer groups are not supported")
+	case t.width <= 0 || t.height <= 0 || t.tileW <= 0 || t.tileH <= 0:
+		return nil, fmt.Errorf("map has no size")
+	case len(t.layers) == 0:
+		return nil, fmt.Errorf("map has no tile layers")
+	}
+	sort.Slice(t.tilesets, func(i, j int) bool { return t.tilesets[i].firstGID < t.tilesets[j].firstGID })
+
+	grid := make([][]Tile, t.height)
+	zones := make([][]Zone, t.height)
+	for y := range grid {
+		grid[y] = make([]Tile, t.width)
+		zones[y] = make([]Zone, t.width)
+	}
+	for _, l := range t.layers {
+		if len(l.gids) != t.width*t.height {
+			return nil, fmt.Errorf("layer %q has %d tiles, want %d", l.name, len(l.gids), t.width*t.height)
+		}
+		for i, gid := range l.gids {
+			gid &= tiledGIDMask
+			if gid == 0 {
+				continue // nothing drawn on this layer
+			}
+			tile, err := t.tileFor(gid)
+			if err != nil {
+				return nil, fmt.Errorf("layer %q row %d column %d: %w", l.name, i/t.width+1, i%t.width+1, err)
+			}
+			grid[i/t.width][i%t.width] = tile
+		}
+	}
+
+	var spawns Spawns
+	for _, o := range t.objects {
+		if err := t.applyObject(o, grid, zones, &spawns); err != nil {
+			return nil, fmt.Errorf("object %q: %w", o.name, err)
+		}
+	}
+	m := fromGrid(grid, zones, spawns, tileSize)
+	for _, c := range t.colours {
+		if err := m.Palette.set(c[0], c[1]); err != nil {
+			return nil, fmt.Errorf("map property %q: %w", c[0], err)
+		}
+	}
+	return m, nil
+}
+
+// addColours keeps the map background and any map properties that name a
+// palette colour; other properties are left alone.
+func (t *tiledMap) addColours(background string, props map[string]string) {
+	if background != "" {
+		t.colours = append(t.colours, [2]string{"background", background})
+	}
+	for _, name := range []string{"wall", "pellet", "background"} {
+		if v, ok := props[name]; ok {
+			t.colours = append(t.colours, [2]string{name, v})
+		}
+	}
+}
+
+// tileFor resolves a global tile ID through the tilesets.
+func (t *tiledMap) tileFor(gid uint32) (Tile, error) {
+	var ts *tiledTileset
+	for i := range t.tilesets {
+		if t.tilesets[i].firstGID <= gid {
+			ts = &t.tilesets[i]
+		}
+	}
+	if ts == nil {
+		return 0, fmt.Errorf("unknown tile GID %d: not in any tileset", gid)
+	}
+	local := gid - ts.firstGID
+	class, ok := ts.kinds[local]
+	if !ok {
+		return 0, fmt.Errorf("unknown tile GID %d: tile %d of tileset %q has no class; set it to one of %s", gid, local, ts.name, tiledKindList())
+	}
+	tile, ok := tiledTileKinds[strings.ToLower(class)]
+	if !ok {
+		return 0, fmt.Errorf("unknown tile GID %d: tile %d of tileset %q has class %q; want one of %s", gid, local, ts.name, class, tiledKindList())
+	}
+	return tile, nil
+}
+
+func tiledKindList() string {
+	names := make([]string, 0, len(tiledTileKinds))
+	for name := range tiledTileKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// applyObject records what an object marks on the map.
+func (t *tiledMap) applyObject(o tiledObject, grid [][]Tile, zones [][]Zone, spawns *Spawns) error {
+	var zone Zone
+	var slot **Point
+	switch strings.ToLower(o.class) {
+	case "":
+		return nil
+	case "player":
+		slot = &spawns.Player
+	case "fruit":
+		slot = &spawns.Fruit
+	case "ghost":
+		i, ok := tiledGhostNames[strings.ToLower(o.name)]
+		if !ok {
+			return fmt.Errorf("ghost objects must be named red, pink, cyan or orange")
+		}
+		slot = &spawns.Ghosts[i]
+	case "tunnel":
+		zone = ZoneTunnel
+	case "noup":
+		zone = ZoneNoUp
+	default:
+		return fmt.Errorf("unknown class %q", o.class)
+	}
+
+	top := o.y
+	if o.tile {
+		top -= o.h
+	}
+	if slot != nil {
+		p := Point{X: int(math.Floor((o.x + o.w/2) / float64(t.tileW))), Y: int(math.Floor((top + o.h/2) / float64(t.tileH)))}
+		if p.X < 0 || p.Y < 0 || p.X >= t.width || p.Y >= t.height {
+			return fmt.Errorf("lies outside the map")
+		}
+		if *slot != nil {
+			return fmt.Errorf("%s is marked more than once", strings.ToLower(o.class))
+		}
+		*slot = &p
+		grid[p.Y][p.X] = TileEmpty
+		return nil
+	}
+
+	x0, y0 := int(math.Floor(o.x/float64(t.tileW))), int(math.Floor(top/float64(t.tileH)))
+	x1, y1 := x0, y0
+	if o.w > 0 && o.h > 0 {
+		x1 = int(math.Ceil((o.x+o.w)/float64(t.tileW))) - 1
+		y1 = int(math.Ceil((top+o.h)/float64(t.tileH))) - 1
+	}
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			if x >= 0 && y >= 0 && x < t.width && y < t.height {
+				zones[y][x] |= zone
+			}
+		}
+	}
+	return nil
+}
+
+// decodeTiledData decodes base64 layer data, optionally compressed, into
+// global tile IDs.
+func decodeTiledData(data, compression string) ([]uint32, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding layer data: %w", err)
+	}
+	var zr io.ReadCloser
+	switch compression {
+	case "":
+	case "zlib":
+		zr, err = zlib.NewReader(bytes.NewReader(raw))
+	case "gzip":
+		zr, err = gzip.NewReader(bytes.NewReader(raw))
+	default:
+		return nil, fmt.Errorf("unsupported layer compression %q", compression)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decompressing layer data: %w", err)
+	}
+	if zr != nil {
+		defer zr.Close()
+		if raw, err = io.ReadAll(zr); err != nil {
+			return nil, fmt.Errorf("decompressing layer data: %w", err)
+		}
+	}
+	if len(raw)%4 != 0 {
+		return nil, fmt.Errorf("layer data is %d bytes, not whole tiles", len(raw))
+	}
+	gids := make([]uint32, len(raw)/4)
+	for i := range gids {
+		gids[i] = binary.LittleEndian.Uint32(raw[4*i:])
+	}
+	return gids, nil
+}
+
+// loadExternalTileset reads a tileset saved in its own file, as XML (.tsx)
+// or JSON.
+func loadExternalTileset(source string, firstGID uint32, open opener) (tiledTileset, error) {
+	if open == nil {
+		return tiledTileset{}, fmt.Errorf("external tileset %q can only be read with LoadFromFile", source)
+	}
+	f, err := open(source)
+	if err != nil {
+		return tiledTileset{}, fmt.Errorf("tileset: %w", err)
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(source), ".tsx") {
+		var ts tmxTileset
+		if err := xml.NewDecoder(f).Decode(&ts); err != nil {
+			return tiledTileset{}, fmt.Errorf("reading tileset %q: %w", source, err)
+		}
+		ts.FirstGID = firstGID
+		return ts.toTiled(), nil
+	}
+	var ts tmjTileset
+	if err := json.NewDecoder(f).Decode(&ts); err != nil {
+		return tiledTileset{}, fmt.Errorf("reading tileset %q: %w", source, err)
+	}
+	ts.FirstGID = firstGID
+	return ts.toTiled(), nil
+}
+
+// TMX (XML) documents.
+
+type tmxMap struct {
+	Width        int              `xml:"width,attr"`
+	Height       int              `xml:"height,attr"`
+	TileWidth    int              `xml:"tilewidth,attr"`
+	TileHeight   int              `xml:"tileheight,attr"`
+	Infinite     int              `xml:"infinite,attr"`
+	Background   string           `xml:"backgroundcolor,attr"`
+	Properties   []tmxProperty    `xml:"properties>property"`
+	Tilesets     []tmxTileset     `xml:"tileset"`
+	Layers       []tmxLayer       `xml:"layer"`
+	ObjectGroups []tmxObjectGroup `xml:"objectgroup"`
+	Groups       []struct{}       `xml:"group"`
+}
+
+type tmxTileset struct {
+	FirstGID uint32    `xml:"firstgid,attr"`
+	Source   string    `xml:"source,attr"`
+	Name     string    `xml:"name,attr"`
+	Tiles    []tmxTile `xml:"tile"`
+}
+
+type tmxTile struct {
+	ID         uint32        `xml:"id,attr"`
+	Type       string        `xml:"type,attr"`
+	Class      string        `xml:"class,attr"`
+	Properties []tmxProperty `xml:"properties>property"`
+}
+
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type tmxLayer struct {
+	Name string  `xml:"name,attr"`
+	Data tmxData `xml:"data"`
+}
+
+type tmxData struct {
+	Encoding    string     `xml:"encoding,attr"`
+	Compression string     `xml:"compression,attr"`
+	Tiles       []tmxGID   `xml:"tile"`
+	Chunks      []struct{} `xml:"chunk"`
+	Text        string     `xml:",chardata"`
+}
+
+type tmxGID struct {
+	GID uint32 `xml:"gid,attr"`
+}
+
+type tmxObjectGroup struct {
+	Objects []tmxObject `xml:"object"`
+}
+
+type tmxObject struct {
+	Name   string  `xml:"name,attr"`
+	Type   string  `xml:"type,attr"`
+	Class  string  `xml:"class,attr"`
+	X      float64 `xml:"x,attr"`
+	Y      float64 `xml:"y,attr"`
+	Width  float64 `xml:"width,attr"`
+	Height float64 `xml:"height,attr"`
+	GID    uint32  `xml:"gid,attr"`
+}
+
+func (x *tmxMap) toTiled(open opener) (*tiledMap, error) {
+	t := &tiledMap{
+		width: x.Width, height: x.Height, tileW: x.TileWidth, tileH: x.TileHeight,
+		infinite: x.Infinite != 0, group: len(x.Groups) > 0,
+	}
+	props := make(map[string]string)
+	for _, p := range x.Properties {
+		props[p.Name] = p.Value
+	}
+	t.addColours(x.Background, props)
+	for _, ts := range x.Tilesets {
+		if ts.Source != "" {
+			ext, err := loadExternalTileset(ts.Source, ts.FirstGID, open)
+			if err != nil {
+				return nil, err
+			}
+			t.tilesets = append(t.tilesets, ext)
+			continue
+		}
+		t.tilesets = append(t.tilesets, ts.toTiled())
+	}
+	for _, l := range x.Layers {
+		if len(l.Data.Chunks) > 0 {
+			t.infinite = true
+			continue
+		}
+		gids, err := l.Data.gids()
+		if err != nil {
+			return nil, fmt.Errorf("layer %q: %w", l.Name, err)
+		}
+		t.layers = append(t.layers, tiledLayer{name: l.Name, gids: gids})
+	}
+	for _, g := range x.ObjectGroups {
+		for _, o := range g.Objects {
+			class := o.Class
+			if class == "" {
+				class = o.Type
+			}
+			t.objects = append(t.objects, tiledObject{name: o.Name, class: class, x: o.X, y: o.Y, w: o.Width, h: o.Height, tile: o.GID != 0})
+		}
+	}
+	return t, nil
+}
+
+func (ts tmxTileset) toTiled() tiledTileset {
+	out := tiledTileset{name: ts.Name, firstGID: ts.FirstGID, kinds: make(map[uint32]string)}
+	for _, tile := range ts.Tiles {
+		class := tile.Class
+		if class == "" {
+			class = tile.Type
+		}
+		for _, p := range tile.Properties {
+			if p.Name == "tile" {
+				class = p.Value
+			}
+		}
+		if class != "" {
+			out.kinds[tile.ID] = class
+		}
+	}
+	return out
+}
+
+func (d tmxData) gids() ([]uint32, error) {
+	switch d.Encoding {
+	case "":
+		out := make([]uint32, len(d.Tiles))
+		for i, t := range d.Tiles {
+			out[i] = t.GID
+		}
+		return out, nil
+	case "csv":
+		var out []uint32
+		for _, f := range strings.Split(d.Text, ",") {
+			f = strings.TrimSpace(f)
+			if f == "" {
+				continue
+			}
+			n, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("bad CSV tile %q", f)
+			}
+			out = append(out, uint32(n))
+		}
+		return out, nil
+	case "base64":
+		return decodeTiledData(d.Text, d.Compression)
+	default:
+		return nil, fmt.Errorf("unsupported layer encoding %q", d.Encoding)
+	}
+}
+
+// TMJ (JSON) documents.
+
+type tmjMap struct {
+	Width      int           `json:"width"`
+	Height     int           `json:"height"`
+	TileWidth  int           `json:"tilewidth"`
+	TileHeight int           `json:"tileheight"`
+	Infinite   bool          `json:"infinite"`
+	Background string        `json:"backgroundcolor"`
+	Properties []tmjProperty `json:"properties"`
+	Tilesets   []tmjTileset  `json:"tilesets"`
+	Layers     []tmjLayer    `json:"layers"`
+}
+
+type tmjTileset struct {
+	FirstGID uint32    `json:"firstgid"`
+	Source   string    `json:"source"`
+	Name     string    `json:"name"`
+	Tiles    []tmjTile `json:"tiles"`
+}
+
+type tmjTile struct {
+	ID         uint32        `json:"id"`
+	Type       string        `json:"type"`
+	Class      string        `json:"class"`
+	Properties []tmjProperty `json:"properties"`
+}
+
+type tmjProperty struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+type tmjLayer struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Data        json.RawMessage `json:"data"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Objects     []tmjObject     `json:"objects"`
+}
+
+type tmjObject struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Class  string  `json:"class"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	GID    uint32  `json:"gid"`
+}
+
+func (j *tmjMap) toTiled(open opener) (*tiledMap, error) {
+	t := &tiledMap{width: j.Width, height: j.Height, tileW: j.TileWidth, tileH: j.TileHeight, infinite: j.Infinite}
+	props := make(map[string]string)
+	for _, p := range j.Properties {
+		if v, ok := p.Value.(string); ok {
+			props[p.Name] = v
+		}
+	}
+	t.addColours(j.Background, props)
+	for _, ts := range j.Tilesets {
+		if ts.Source != "" {
+			ext, err := loadExternalTileset(ts.Source, ts.FirstGID, open)
+			if err != nil {
+				return nil, err
+			}
+			t.tilesets = append(t.tilesets, ext)
+			continue
+		}
+		t.tilesets = append(t.tilesets, ts.toTiled())
+	}
+	for _, l := range j.Layers {
+		switch l.Type {
+		case "tilelayer":
+			gids, err := l.gids()
+			if err != nil {
+				return nil, fmt.Errorf("layer %q: %w", l.Name, err)
+			}
+			t.layers = append(t.layers, tiledLayer{name: l.Name, gids: gids})
+		case "objectgroup":
+			for _, o := range l.Objects {
+				class := o.Class
+				if class == "" {
+					class = o.Type
+				}
+				t.objects = append(t.objects, tiledObject{name: o.Name, class: class, x: o.X, y: o.Y, w: o.Width, h: o.Height, tile: o.GID != 0})
+			}
+		case "group":
+			t.group = true
+		}
+	}
+	return t, nil
+}
+
+func (ts tmjTileset) toTiled() tiledTileset {
+	out := tiledTileset{name: ts.Name, firstGID: ts.FirstGID, kinds: make(map[uint32]string)}
+	for _, tile := range ts.Tiles {
+		class := tile.Class
+		if class == "" {
+			class = tile.Type
+		}
+		for _, p := range tile.Properties {
+			if v, ok := p.Value.(string); ok && p.Name == "tile" {
+				class = v
+			}
+		}
+		if class != "" {
+			out.kinds[tile.ID] = class
+		}
+	}
+	return out
+}
+
+func (l tmjLayer) gids() ([]uint32, error) {
+	if l.Encoding == "base64" {
+		var s string
+		if err := json.Unmarshal(l.Data, &s); err != nil {
+			return nil, fmt.Errorf("base64 layer data is not a string")
+		}
+		return decodeTiledData(s, l.Compression)
+	}
+	var out []uint32
+	if err := json.Unmarshal(l.Data, &out); err != nil {
+		return nil, fmt.Errorf("layer data is not a list of tile IDs")
+	}
+	return out, nil
+}
@@ -0,0 +1,166 @@
+package tilemap
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tiledTiles is an embedded TMX tileset: 1 wall, 2 pellet, 3 power, 4 door
+// (via the "tile" property), 5 empty; 6 has no class.
+const tiledTiles = `<tileset firstgid="1" name="maze" tilewidth="8" tileheight="8" tilecount="6">
+  <tile id="0" class="wall"/>
+  <tile id="1" type="pellet"/>
+  <tile id="2" class="Power"/>
+  <tile id="3"><properties><property name="tile" value="door"/></properties></tile>
+  <tile id="4" class="empty"/>
+ </tileset>`
+
+const tinyTMX = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" orientation="orthogonal" width="4" height="3" tilewidth="8" tileheight="8" infinite="0">
+ ` + tiledTiles + `
+ <layer id="1" name="maze" width="4" height="3">
+  <data encoding="csv">
+1,1,1,1,
+2,3,4,5,
+1,2,2,1
+</data>
+ </layer>
+ <objectgroup id="2" name="markers">
+  <object id="1" name="start" class="player" x="12" y="20"><point/></object>
+  <object id="2" name="pink" type="ghost" x="16" y="8" width="8" height="8"/>
+  <object id="3" class="fruit" gid="1" x="24" y="24" width="8" height="8"/>
+  <object id="4" class="tunnel" x="0" y="8" width="16" height="8"/>
+  <object id="5" name="note" x="0" y="0"/>
+ </objectgroup>
+</map>`
+
+func TestLoadTMX(t *testing.T) {
+	m, err := LoadTMX(strings.NewReader(tinyTMX), 16)
+	if err != nil {
+		t.Fatalf("LoadTMX: %v", err)
+	}
+	if m.Width != 4 || m.Height != 3 || m.TileSize != 16 {
+		t.Fatalf("unexpected size %dx%d tile %d", m.Width, m.Height, m.TileSize)
+	}
+	want := [][]Tile{
+		{TileWall, TileWall, TileWall, TileWall},
+		{TilePellet, TilePower, TileEmpty, TileEmpty},
+		{TileWall, TileEmpty, TilePellet, TileEmpty}, // player and fruit spots are cleared
+	}
+	for y := range want {
+		for x := range want[y] {
+			if m.Tiles[y][x] != want[y][x] {
+				t.Errorf("tile (%d,%d) = %v, want %v", x, y, m.Tiles[y][x], want[y][x])
+			}
+		}
+	}
+	if p := m.Spawns.Player; p == nil || *p != (Point{1, 2}) {
+		t.Errorf("player spawn = %v, want (1,2)", p)
+	}
+	if p := m.Spawns.Ghosts[1]; p == nil || *p != (Point{2, 1}) {
+		t.Errorf("pink ghost spawn = %v, want (2,1)", p)
+	}
+	if p := m.Spawns.Fruit; p == nil || *p != (Point{3, 2}) {
+		t.Errorf("fruit = %v, want (3,2)", p)
+	}
+	if !m.IsTunnel(0, 1) || !m.IsTunnel(1, 1) || m.IsTunnel(2, 1) {
+		t.Error("expected the tunnel object to cover exactly (0,1) and (1,1)")
+	}
+	if m.PelletsRemaining() != 3 {
+		t.Errorf("expected 3 pellets once the spawn tile is cleared, got %d", m.PelletsRemaining())
+	}
+}
+
+func TestLoadTMJ(t *testing.T) {
+	var raw bytes.Buffer
+	zw := zlib.NewWriter(&raw)
+	for _, gid := range []uint32{1, 2, 3 | 0x80000000, 1} { // flip flags are ignored
+		_ = binary.Write(zw, binary.LittleEndian, gid)
+	}
+	zw.Close()
+	src := `{
+  "width": 4, "height": 1, "tilewidth": 8, "tileheight": 8, "infinite": false,
+  "tilesets": [{"firstgid": 1, "name": "maze", "tiles": [
+    {"id": 0, "type": "wall"}, {"id": 1, "class": "pellet"},
+    {"id": 2, "properties": [{"name": "tile", "type": "string", "value": "power"}]}]}],
+  "layers": [
+    {"type": "tilelayer", "name": "maze", "width": 4, "height": 1, "encoding": "base64",
+     "compression": "zlib", "data": "` + base64.StdEncoding.EncodeToString(raw.Bytes()) + `"},
+    {"type": "tilelayer", "name": "overlay", "width": 4, "height": 1, "data": [0, 0, 0, 2]},
+    {"type": "objectgroup", "name": "markers", "objects": [
+      {"name": "red", "type": "ghost", "x": 4, "y": 4, "point": true},
+      {"class": "noup", "x": 8, "y": 0, "width": 8, "height": 8}]}
+  ]
+}`
+	m, err := LoadTMJ(strings.NewReader(src), 16)
+	if err != nil {
+		t.Fatalf("LoadTMJ: %v", err)
+	}
+	want := []Tile{TileEmpty, TilePellet, TilePower, TilePellet}
+	for x, w := range want {
+		if m.Tiles[0][x] != w {
+			t.Errorf("tile %d = %v, want %v", x, m.Tiles[0][x], w)
+		}
+	}
+	if p := m.Spawns.Ghosts[0]; p == nil || *p != (Point{0, 0}) {
+		t.Errorf("red ghost spawn = %v, want (0,0)", p)
+	}
+	if !m.IsNoUp(1, 0) || m.IsNoUp(2, 0) {
+		t.Error("expected the noup object to cover only (1,0)")
+	}
+}
+
+func TestTiledErrors(t *testing.T) {
+	withLayer := func(csv, objects string) string {
+		return `<map width="2" height="1" tilewidth="8" tileheight="8">` + tiledTiles +
+			`<layer name="maze"><data encoding="csv">` + csv + `</data></layer>` +
+			`<objectgroup>` + objects + `</objectgroup></map>`
+	}
+	cases := []struct {
+		name, src, want string
+	}{
+		{"gid past tileset", withLayer("1,9", ""), `layer "maze" row 1 column 2: unknown tile GID 9: tile 8 of tileset "maze" has no class`},
+		{"tile without class", withLayer("6,1", ""), "unknown tile GID 6: tile 5 of tileset \"maze\" has no class; set it to one of door, empty, pellet, power, wall"},
+		{"no tileset", `<map width="1" height="1" tilewidth="8" tileheight="8"><layer name="maze"><data encoding="csv">1</data></layer></map>`, "unknown tile GID 1: not in any tileset"},
+		{"wrong size", withLayer("1", ""), `layer "maze" has 1 tiles, want 2`},
+		{"unknown object", withLayer("1,1", `<object name="x" class="pacman" x="0" y="0"/>`), `object "x": unknown class "pacman"`},
+		{"unnamed ghost", withLayer("1,1", `<object class="ghost" x="0" y="0"/>`), "ghost objects must be named"},
+		{"infinite", `<map width="2" height="1" tilewidth="8" tileheight="8" infinite="1"></map>`, "infinite maps are not supported"},
+		{"external tileset", `<map width="1" height="1" tilewidth="8" tileheight="8"><tileset firstgid="1" source="maze.tsx"/></map>`, "can only be read with LoadFromFile"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := LoadTMX(strings.NewReader(c.src), 16)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Fatalf("expected error containing %q, got %v", c.want, err)
+			}
+		})
+	}
+}
+
+func TestLoadFromFileTiledExternalTileset(t *testing.T) {
+	dir := t.TempDir()
+	tsx := `<?xml version="1.0" encoding="UTF-8"?>` + strings.Replace(tiledTiles, ` firstgid="1"`, "", 1)
+	tmx := `<map width="2" height="1" tilewidth="8" tileheight="8"><tileset firstgid="1" source="maze.tsx"/>` +
+		`<layer name="maze"><data encoding="csv">1,2</data></layer></map>`
+	if err := os.WriteFile(filepath.Join(dir, "maze.tsx"), []byte(tsx), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "board.tmx")
+	if err := os.WriteFile(path, []byte(tmx), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadFromFile(path, 16)
+	if err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	if m.Tiles[0][0] != TileWall || m.Tiles[0][1] != TilePellet {
+		t.Fatalf("unexpected tiles %v", m.Tiles[0])
+	}
+}
@@ -14,6 +14,15 @@ const (
 	TileWall
 	TilePellet
 	TilePower
+	TileDoor // ghost house door: solid for the player, crossed only by ghosts
+)
+
+// Zone flags mark tiles where ghosts follow special movement rules.
+type Zone uint8
+
+const (
+	ZoneTunnel Zone = 1 << iota // ghosts slow down
+	ZoneNoUp                    // ghosts may not turn up unless frightened
 )
 
 type TileMap struct {
@@ -21,23 +30,110 @@ type TileMap struct {
 	Height   int
 	TileSize int
 	Tiles    [][]Tile
+	Zones    [][]Zone
+	Spawns   Spawns // positions as marked in the maze
+	Layout   Layout // where everything starts, marked or worked out
+	Palette  Palette
+
+	pellets int           // pellets and power pellets left to eat
+	initial [][]Tile      // layout as loaded, used to refill the board
+	walls   *ebiten.Image // wall outlines in white, rendered on first draw
 }
 
 func NewDefaultMap(tileSize int) *TileMap {
-	grid := parseMaze(defaultMaze)
-	return &TileMap{
-		Width:    len(grid[0]),
-		Height:   len(grid),
-		TileSize: tileSize,
-		Tiles:    grid,
+	m, err := newMap(defaultMaze, nil, tileSize)
+	if err != nil {
+		panic("tilemap: bad default maze: " + err.Error())
+	}
+	return m
+}
+
+// Clone returns an independent copy of the map with a full board, e.g. to
+// start a new game on a loaded maze.
+func (m *TileMap) Clone() *TileMap {
+	c := *m
+	c.Tiles = copyTiles(m.initial)
+	c.Zones = make([][]Zone, len(m.Zones))
+	for y, row := range m.Zones {
+		c.Zones[y] = append([]Zone(nil), row...)
+	}
+	c.pellets = c.countPellets()
+	c.walls = nil
+	return &c
+}
+
+// ResetPellets puts every pellet and power pellet back where the maze was
+// loaded with one.
+func (m *TileMap) ResetPellets() {
+	for y, row := range m.initial {
+		for x, t := range row {
+			if t == TilePellet || t == TilePower {
+				m.Tiles[y][x] = t
+			}
+		}
+	}
+	m.pellets = m.countPellets()
+}
+
+func copyTiles(grid [][]Tile) [][]Tile {
+	out := make([][]Tile, len(grid))
+	for y, row := range grid {
+		out[y] = append([]Tile(nil), row...)
+	}
+	return out
+}
+
+// PelletsRemaining returns how many pellets, power pellets included, are
+// still on the board.
+func (m *TileMap) PelletsRemaining() int {
+	return m.pellets
+}
+
+func (m *TileMap) countPellets() int {
+	n := 0
+	for _, row := range m.Tiles {
+		for _, t := range row {
+			if t == TilePellet || t == TilePower {
+				n++
+			}
+		}
 	}
+	return n
 }
 
+// IsWall reports whether a cell blocks movement. The ghost house door counts
+// as a wall; ghosts that are allowed through it are moved by the game directly.
 func (m *TileMap) IsWall(x, y int) bool {
 	if y < 0 || y >= m.Height || x < 0 || x >= m.Width {
 		return true
 	}
-	return m.Tiles[y][x] == TileWall
+	return m.Tiles[y][x] == TileWall || m.Tiles[y][x] == TileDoor
+}
+
+// IsDoor reports whether a cell is part of the ghost house door.
+func (m *TileMap) IsDoor(x, y int) bool {
+	if y < 0 || y >= m.Height || x < 0 || x >= m.Width {
+		return false
+	}
+	return m.Tiles[y][x] == TileDoor
+}
+
+// ZoneAt returns the zone flags of a cell; cells outside the map have none.
+func (m *TileMap) ZoneAt(x, y int) Zone {
+	if y < 0 || y >= len(m.Zones) || x < 0 || x >= len(m.Zones[y]) {
+		return 0
+	}
+	return m.Zones[y][x]
+}
+
+// IsTunnel reports whether a cell slows ghosts down.
+func (m *TileMap) IsTunnel(x, y int) bool {
+	return m.ZoneAt(x, y)&ZoneTunnel != 0
+}
+
+// IsNoUp reports whether ghosts are barred from turning up at a cell.
+func (m *TileMap) IsNoUp(x, y int) bool {
+	return m.ZoneAt(x, y)&ZoneNoUp != 0
 }
 
 // EatPelletAt removes a pellet/power pellet at grid cell and returns (ate, power)
@@ -45,20 +141,36 @@ func (m *TileMap) EatPelletAt(x, y int) (bool, bool) {
 	if y < 0 || y >= m.Height || x < 0 || x >= m.Width {
 		return false, false
 	}
-	if m.Tiles[y][x] == TilePellet {
-		m.Tiles[y][x] = TileEmpty
-		return true, false
+	t := m.Tiles[y][x]
+	if t != TilePellet && t != TilePower {
+		return false, false
 	}
-	if m.Tiles[y][x] == TilePower {
-		m.Tiles[y][x] = TileEmpty
-		return true, true
+	m.Tiles[y][x] = TileEmpty
+	if m.pellets > 0 {
+		m.pellets--
 	}
-	return false, false
+	return true, t == TilePower
 }
 
+// Draw draws the maze in its palette. The background is left to the caller.
 func (m *TileMap) Draw(dst *ebiten.Image) {
-	blue := color.RGBA{R: 33, G: 33, B: 255, A: 255}
-	pelletColor := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	m.DrawWithWallColor(dst, m.Palette.Wall)
+}
+
+// DrawWithWallColor draws the maze with walls in the given colour, e.g. to
+// flash the board when it is cleared. The walls are rendered once, the first
+// time the map is drawn, and tinted from then on; only the door and pellets
+// are drawn every frame. Walls changed in Tiles after that need RedrawWalls.
+func (m *TileMap) DrawWithWallColor(dst *ebiten.Image, wall color.Color) {
+	pelletColor := m.Palette.Pellet
+	doorColor := color.RGBA{R: 255, G: 184, B: 222, A: 255}
+
+	if m.walls == nil {
+		m.walls = m.renderWalls()
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.ColorScale.ScaleWithColor(wall)
+	dst.DrawImage(m.walls, op)
 
 	for y := 0; y < m.Height; y++ {
 		for x := 0; x < m.Width; x++ {
@@ -69,13 +181,8 @@ func (m *TileMap) Draw(dst *ebiten.Image) {
 			cy := py + float32(m.TileSize/2)
 
 			switch t {
-			case TileWall:
-				// Draw a filled rectangle for the wall
-				rect := ebiten.NewImage(m.TileSize, m.TileSize)
-				rect.Fill(blue)
-				op := &ebiten.DrawImageOptions{}
-				op.GeoM.Translate(float64(px), float64(py))
-				dst.DrawImage(rect, op)
+			case TileDoor:
+				vector.DrawFilledRect(dst, px, cy-1, float32(m.TileSize), 2, doorColor, false)
 			case TilePellet:
 				vector.DrawFilledCircle(dst, cx, cy, float32(m.TileSize)/8, pelletColor, true)
 			case TilePower:
@@ -85,24 +192,8 @@ func (m *TileMap) Draw(dst *ebiten.Image) {
 	}
 }
 
-func parseMaze(lines []string) [][]Tile {
-	h := len(lines)
-	w := len(lines[0])
-	grid := make([][]Tile, h)
-	for y := 0; y < h; y++ {
-		grid[y] = make([]Tile, w)
-		for x := 0; x < w; x++ {
-			switch lines[y][x] {
-			case '#':
-				grid[y][x] = TileWall
-			case '.':
-				grid[y][x] = TilePellet
-			case 'o':
-				grid[y][x] = TilePower
-			default:
-				grid[y][x] = TileEmpty
-			}
-		}
-	}
-	return grid
+// RedrawWalls drops the rendered walls so the next draw renders them again,
+// for when walls are changed in Tiles directly.
+func (m *TileMap) RedrawWalls() {
+	m.walls = nil
 }
@@ -41,3 +41,86 @@ func TestIsWallBounds(t *testing.T) {
 		t.Fatalf("out-of-bounds should be treated as wall")
 	}
 }
+
+func TestGhostDoorIsSolid(t *testing.T) {
+	m := NewDefaultMap(16)
+	doors := 0
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if m.IsDoor(x, y) {
+				doors++
+				if !m.IsWall(x, y) {
+					t.Fatalf("door at %d,%d should block movement", x, y)
+				}
+			}
+		}
+	}
+	if doors == 0 {
+		t.Fatal("default map should have a ghost house door")
+	}
+	if m.IsDoor(-1, 0) {
+		t.Fatal("out-of-bounds should not be a door")
+	}
+}
+
+func TestPelletsRemaining(t *testing.T) {
+	m := NewDefaultMap(16)
+	want := 0
+	px, py := -1, -1
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			if m.Tiles[y][x] == TilePellet || m.Tiles[y][x] == TilePower {
+				want++
+				px, py = x, y
+			}
+		}
+	}
+	if got := m.PelletsRemaining(); got != want {
+		t.Fatalf("expected %d pellets, got %d", want, got)
+	}
+	m.EatPelletAt(px, py)
+	m.EatPelletAt(px, py)
+	if got := m.PelletsRemaining(); got != want-1 {
+		t.Fatalf("expected %d pellets after eating one, got %d", want-1, got)
+	}
+}
+
+func TestMazeZones(t *testing.T) {
+	m := NewDefaultMap(16)
+	for _, c := range [][2]int{{0, 14}, {5, 14}, {22, 14}, {27, 14}} {
+		if !m.IsTunnel(c[0], c[1]) || m.IsWall(c[0], c[1]) {
+			t.Fatalf("expected open tunnel tile at %d,%d", c[0], c[1])
+		}
+	}
+	if m.IsTunnel(6, 14) {
+		t.Fatal("pellet tile next to the tunnel should not be a tunnel")
+	}
+	for _, c := range [][2]int{{12, 11}, {15, 11}, {12, 26}, {15, 26}} {
+		if !m.IsNoUp(c[0], c[1]) {
+			t.Fatalf("expected no-up intersection at %d,%d", c[0], c[1])
+		}
+	}
+	if m.Tiles[26][12] != TilePellet {
+		t.Fatal("no-up marker ':' should keep its pellet")
+	}
+	if m.ZoneAt(-1, 0) != 0 || m.ZoneAt(0, m.Height) != 0 {
+		t.Fatal("cells outside the map should have no zone")
+	}
+}
+
+func TestResetPellets(t *testing.T) {
+	m := NewDefaultMap(16)
+	full := m.PelletsRemaining()
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			m.EatPelletAt(x, y)
+		}
+	}
+	if m.PelletsRemaining() != 0 {
+		t.Fatalf("expected an empty board, got %d pellets", m.PelletsRemaining())
+	}
+	m.ResetPellets()
+	if got := m.PelletsRemaining(); got != full {
+		t.Fatalf("expected %d pellets after reset, got %d", full, got)
+	}
+}
@@ -0,0 +1,119 @@
+package tilemap
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Walls are drawn arcade style: two thin lines run along every side of a
+// wall that faces open space, bending round convex corners and curling into
+// concave ones. The lines are worked out a quarter tile at a time from the
+// neighbours of each wall tile.
+
+// wallInsets are how far the two outline lines sit from the open side of a
+// wall, as fractions of a tile. Both must stay under half a tile so convex
+// corners keep a radius.
+var wallInsets = [2]float32{0.2, 0.4}
+
+// wallArcSteps is how many straight pieces draw a quarter circle.
+const wallArcSteps = 4
+
+// wallLine is a straight piece of outline in map pixels.
+type wallLine struct {
+	x0, y0, x1, y1 float32
+}
+
+// wallArc is a quarter circle around (cx, cy) of radius r, running from the
+// direction (ux, uy) to the direction (vx, vy).
+type wallArc struct {
+	cx, cy, r      float32
+	ux, uy, vx, vy float32
+}
+
+// isOpen reports whether walls should be outlined towards a cell. Cells off
+// the map count as wall so the outline stops at the edge of the screen; the
+// door counts as open so the house walls are capped either side of it.
+func (m *TileMap) isOpen(x, y int) bool {
+	if y < 0 || y >= m.Height || x < 0 || x >= m.Width {
+		return false
+	}
+	return m.Tiles[y][x] != TileWall
+}
+
+// wallOutline returns the outline pieces of the wall tile at x, y. Each
+// quarter of the tile looks at its side neighbour, its upper or lower
+// neighbour and the diagonal between them:
+//
+//	side and up/down open   convex corner, arc round the tile centre
+//	only one of them open   straight line along that side
+//	only the diagonal open  concave corner, arc round the tile corner
+func (m *TileMap) wallOutline(x, y int) ([]wallLine, []wallArc) {
+	if m.isOpen(x, y) {
+		return nil, nil
+	}
+	var lines []wallLine
+	var arcs []wallArc
+	t := float32(m.TileSize)
+	half := t / 2
+	cx, cy := float32(x)*t+half, float32(y)*t+half
+	for _, dy := range []int{-1, 1} {
+		for _, dx := range []int{-1, 1} {
+			fx, fy := float32(dx), float32(dy)
+			// Outer corner of this quarter, on the tile's edge
+			ex, ey := cx+fx*half, cy+fy*half
+			side, vert := m.isOpen(x+dx, y), m.isOpen(x, y+dy)
+			diag := m.isOpen(x+dx, y+dy)
+			for _, f := range wallInsets {
+				in := f * t
+				switch {
+				case side && vert:
+					arcs = append(arcs, wallArc{cx, cy, half - in, fx, 0, 0, fy})
+				case side:
+					lines = append(lines, wallLine{ex - fx*in, cy, ex - fx*in, ey})
+				case vert:
+					lines = append(lines, wallLine{cx, ey - fy*in, ex, ey - fy*in})
+				case diag:
+					arcs = append(arcs, wallArc{ex, ey, in, -fx, 0, 0, -fy})
+				}
+			}
+		}
+	}
+	return lines, arcs
+}
+
+// renderWalls draws the outline of every wall in white onto a new image the
+// size of the map, to be tinted when it is drawn.
+func (m *TileMap) renderWalls() *ebiten.Image {
+	img := ebiten.NewImage(m.Width*m.TileSize, m.Height*m.TileSize)
+	width := float32(m.TileSize) / 16
+	if width < 1 {
+		width = 1
+	}
+	for y := 0; y < m.Height; y++ {
+		for x := 0; x < m.Width; x++ {
+			lines, arcs := m.wallOutline(x, y)
+			for _, l := range lines {
+				vector.StrokeLine(img, l.x0, l.y0, l.x1, l.y1, width, color.White, true)
+			}
+			for _, a := range arcs {
+				px, py := a.point(0)
+				for i := 1; i <= wallArcSteps; i++ {
+					qx, qy := a.point(float64(i) / wallArcSteps)
+					vector.StrokeLine(img, px, py, qx, qy, width, color.White, true)
+					px, py = qx, qy
+				}
+			}
+		}
+	}
+	return img
+}
+
+// point returns the point a fraction f of the way along the arc.
+func (a wallArc) point(f float64) (float32, float32) {
+	s, c := math.Sincos(f * math.Pi / 2)
+	u, v := float32(c)*a.r, float32(s)*a.r
+	return a.cx + u*a.ux + v*a.vx, a.cy + u*a.uy + v*a.vy
+}
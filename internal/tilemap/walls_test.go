@@ -0,0 +1,95 @@
+package tilemap
+
+import (
+	"strings"
+	"testing"
+)
+
+func wallMap(t *testing.T, rows ...string) *TileMap {
+	t.Helper()
+	m, err := LoadFromReader(strings.NewReader(strings.Join(rows, "\n")), 16)
+	if err != nil {
+		t.Fatalf("LoadFromReader: %v", err)
+	}
+	return m
+}
+
+func TestWallOutlineShapes(t *testing.T) {
+	cases := []struct {
+		name       string
+		rows       []string
+		lines, arc int
+	}{
+		{"lone block rounds every corner", []string{"   ", " # ", "   "}, 0, 8},
+		{"buried wall has no outline", []string{"###", "###", "###"}, 0, 0},
+		{"bar runs straight along both sides", []string{"   ", "###", "   "}, 8, 0},
+		{"open side gets lines on one half", []string{"###", "###", "   "}, 4, 0},
+		{"inner corner curls in", []string{"## ", "###", "###"}, 0, 2},
+		{"outer corner of an L", []string{"   ", " ##", " # "}, 4, 4},
+	}
+	for _, c := range cases {
+		m := wallMap(t, c.rows...)
+		lines, arcs := m.wallOutline(1, 1)
+		if len(lines) != c.lines || len(arcs) != c.arc {
+			t.Errorf("%s: %d lines and %d arcs, want %d and %d", c.name, len(lines), len(arcs), c.lines, c.arc)
+		}
+	}
+}
+
+func TestWallOutlineOnlyForWalls(t *testing.T) {
+	m := wallMap(t, "###", "#-#", "#.#")
+	for _, p := range []Point{{1, 1}, {1, 2}} {
+		if lines, arcs := m.wallOutline(p.X, p.Y); lines != nil || arcs != nil {
+			t.Errorf("tile %v is not a wall but has an outline", p)
+		}
+	}
+	// The walls beside the door are capped towards it
+	if lines, _ := m.wallOutline(0, 1); len(lines) != 4 {
+		t.Errorf("wall beside the door has %d lines, want 4", len(lines))
+	}
+}
+
+func TestWallOutlineIsClosed(t *testing.T) {
+	// Around a block every piece must end where another one starts, with
+	// the arcs meeting the straight sides of the quarters next to them
+	m := wallMap(t, "    ", " ## ", " ## ", "    ")
+	ends := map[[2]float32]int{}
+	end := func(x, y float32) {
+		ends[[2]float32{roundPixel(x), roundPixel(y)}]++
+	}
+	for _, p := range []Point{{1, 1}, {2, 1}, {1, 2}, {2, 2}} {
+		lines, arcs := m.wallOutline(p.X, p.Y)
+		for _, l := range lines {
+			end(l.x0, l.y0)
+			end(l.x1, l.y1)
+		}
+		for _, a := range arcs {
+			end(a.point(0))
+			end(a.point(1))
+		}
+	}
+	if len(ends) == 0 {
+		t.Fatal("block has no outline")
+	}
+	for p, n := range ends {
+		if n != 2 {
+			t.Errorf("%d pieces end at %v, want 2", n, p)
+		}
+	}
+}
+
+func roundPixel(f float32) float32 {
+	return float32(int(f*100+0.5)) / 100
+}
+
+func TestCloneRedrawsWalls(t *testing.T) {
+	m := NewDefaultMap(16)
+	m.walls = m.renderWalls()
+	if m.Clone().walls != nil {
+		t.Fatal("a clone should render its own walls")
+	}
+	m.RedrawWalls()
+	if m.walls != nil {
+		t.Fatal("RedrawWalls should drop the rendered walls")
+	}
+}